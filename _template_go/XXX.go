@@ -0,0 +1,15 @@
+package {{.Package}}
+
+import "github.com/kentquirk/aoc2024/aoc"
+
+func part1(lines []string) int {
+	return 0
+}
+
+func part2(lines []string) int {
+	return 0
+}
+
+func init() {
+	aoc.Register({{.Day}}, aoc.Parts{aoc.OnLines(part1), aoc.OnLines(part2)})
+}
@@ -0,0 +1,15 @@
+package {{.Package}}
+
+import (
+	"testing"
+
+	"github.com/kentquirk/aoc2024/aoc/aoctest"
+)
+
+func TestAnswers(t *testing.T) {
+	aoctest.Answers(t, {{.Day}})
+}
+
+func BenchmarkParts(b *testing.B) {
+	aoctest.Benchmark(b, {{.Day}})
+}
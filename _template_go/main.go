@@ -1,39 +0,0 @@
-package main
-
-import (
-	"fmt"
-	"io"
-	"log"
-	"os"
-	"strings"
-)
-
-func part1(lines []string) int {
-	return 0
-}
-
-func part2(lines []string) int {
-	return 0
-}
-
-func readlines(filename string) []string {
-	f, err := os.Open(fmt.Sprintf("./data/%s.txt", filename))
-	if err != nil {
-		log.Fatal(err)
-	}
-	b, err := io.ReadAll(f)
-	if err != nil {
-		log.Fatal(err)
-	}
-	return strings.Split(string(b), "\n")
-}
-
-func main() {
-	args := os.Args[1:]
-	filename := "sample"
-	if len(args) > 0 {
-		filename = args[0]
-	}
-	lines := readlines(filename)
-	fmt.Println(part1(lines))
-}
@@ -0,0 +1,187 @@
+package aoc
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Answers holds the known answers for one input. They live next to the input
+// in data/<name>.answers, in a file like this:
+//
+//	# lines starting with # are comments
+//	part1: 2378066
+//	part2: 18934359
+//	args: 70 1024
+//	wrong1: 2378067
+//
+// A part with no line has no known answer and isn't checked. A part that
+// has no answer to know, like part 2 on the last day, says why with a line
+// like "skip2: there's no part 2 on day 25", and is reported as skipped. The
+// optional
+// args line gives extra arguments to pass to the day along with the input.
+// Each wrong1 or wrong2 line is an answer the site has already turned down,
+// so it isn't sent again.
+type Answers struct {
+	Part1 string
+	Part2 string
+	Args  []string
+	Skip  [2]string // why each part has no answer, if it's been skipped
+	Wrong [2][]string
+}
+
+// AnswersFile returns the path of the answers for a named input in a day's
+// data directory, so "sample" becomes "data/sample.answers".
+func AnswersFile(name string) string {
+	return filepath.Join("data", name+".answers")
+}
+
+// Want returns the expected answer for part 1 or 2, and whether it's known.
+func (a Answers) Want(part int) (string, bool) {
+	switch part {
+	case 1:
+		return a.Part1, a.Part1 != ""
+	case 2:
+		return a.Part2, a.Part2 != ""
+	}
+	return "", false
+}
+
+// Skipped returns the reason part 1 or 2 has no answer, and whether it's
+// been marked as skipped.
+func (a Answers) Skipped(part int) (string, bool) {
+	if part < 1 || part > 2 {
+		return "", false
+	}
+	return a.Skip[part-1], a.Skip[part-1] != ""
+}
+
+// IsWrong reports whether answer is known to be wrong for part 1 or 2.
+func (a Answers) IsWrong(part int, answer string) bool {
+	if part < 1 || part > 2 {
+		return false
+	}
+	return slices.Contains(a.Wrong[part-1], answer)
+}
+
+// ParseAnswers reads an answers file.
+func ParseAnswers(r io.Reader) (Answers, error) {
+	var a Answers
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return a, fmt.Errorf("line %d: expected key: value", n)
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "part1":
+			a.Part1 = value
+		case "part2":
+			a.Part2 = value
+		case "args":
+			a.Args = strings.Fields(value)
+		case "skip1":
+			a.Skip[0] = value
+		case "skip2":
+			a.Skip[1] = value
+		case "wrong1":
+			a.Wrong[0] = append(a.Wrong[0], value)
+		case "wrong2":
+			a.Wrong[1] = append(a.Wrong[1], value)
+		default:
+			return a, fmt.Errorf("line %d: unknown key %q", n, key)
+		}
+	}
+	for part := 1; part <= 2; part++ {
+		_, known := a.Want(part)
+		if _, skipped := a.Skipped(part); known && skipped {
+			return a, fmt.Errorf("part %d has an answer but is also skipped", part)
+		}
+	}
+	return a, scanner.Err()
+}
+
+// ReadAnswers reads the answers file at path.
+func ReadAnswers(path string) (Answers, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Answers{}, err
+	}
+	defer f.Close()
+	a, err := ParseAnswers(f)
+	if err != nil {
+		return a, fmt.Errorf("%s: %w", path, err)
+	}
+	return a, nil
+}
+
+// AnswerInputs returns the names of the inputs in dir's data directory that
+// have an answers file, in sorted order.
+func AnswerInputs(dir string) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, AnswersFile("*")))
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(paths))
+	for i, path := range paths {
+		names[i] = strings.TrimSuffix(filepath.Base(path), ".answers")
+	}
+	return names, nil
+}
+
+// RecordAnswer sets the answer for part 1 or 2 in the answers file at path,
+// replacing the one that's there, if any, and leaving the rest of the file
+// alone. The file is created if it doesn't exist.
+func RecordAnswer(path string, part int, answer string) error {
+	return editAnswers(path, fmt.Sprintf("part%d", part), answer, true)
+}
+
+// RecordWrongAnswer adds an answer that's been turned down for part 1 or 2 to
+// the answers file at path.
+func RecordWrongAnswer(path string, part int, answer string) error {
+	return editAnswers(path, fmt.Sprintf("wrong%d", part), answer, false)
+}
+
+// editAnswers sets key to value in the answers file at path. If replace is
+// set the first line with that key is changed; otherwise, or if there isn't
+// one, a new line is added at the end.
+func editAnswers(path, key, value string, replace bool) error {
+	b, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	line := key + ": " + value
+	lines := strings.Split(strings.TrimSuffix(string(b), "\n"), "\n")
+	if len(b) == 0 {
+		lines = nil
+	}
+	found := false
+	for i, l := range lines {
+		k, _, ok := strings.Cut(l, ":")
+		if replace && ok && strings.TrimSpace(k) == key {
+			lines[i] = line
+			found = true
+			break
+		}
+	}
+	if !found {
+		lines = append(lines, line)
+	}
+	return os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o644)
+}
+
+// FormatAnswer turns a part's result into the form used in answers files.
+func FormatAnswer(v any) string {
+	return fmt.Sprint(v)
+}
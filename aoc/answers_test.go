@@ -0,0 +1,88 @@
+package aoc
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseAnswers(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Answers
+		wantErr bool
+	}{
+		{"empty", "", Answers{}, false},
+		{"both", "part1: 11\npart2: 31\n", Answers{Part1: "11", Part2: "31"}, false},
+		{"comments and args", "# sample\npart2: 6,1\n\nargs: 6 12\n", Answers{Part2: "6,1", Args: []string{"6", "12"}}, false},
+		{"answer with colon", "part1:  a:b \n", Answers{Part1: "a:b"}, false},
+		{"wrong answers", "wrong1: 5\nwrong2: 7\nwrong1: 6\n", Answers{Wrong: [2][]string{{"5", "6"}, {"7"}}}, false},
+		{"skipped", "part1: 3\nskip2: no part 2\n", Answers{Part1: "3", Skip: [2]string{"", "no part 2"}}, false},
+		{"answer and skip", "part2: 3\nskip2: no part 2\n", Answers{}, true},
+		{"unknown key", "part3: 1\n", Answers{}, true},
+		{"no colon", "part1 11\n", Answers{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAnswers(strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseAnswers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseAnswers() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecordAnswer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "input.answers")
+	steps := []struct {
+		name   string
+		record func() error
+		want   string
+	}{
+		{"new file", func() error { return RecordWrongAnswer(path, 1, "12") }, "wrong1: 12\n"},
+		{"add part", func() error { return RecordAnswer(path, 1, "11") }, "wrong1: 12\npart1: 11\n"},
+		{"replace part", func() error { return RecordAnswer(path, 1, "10") }, "wrong1: 12\npart1: 10\n"},
+		{"another wrong", func() error { return RecordWrongAnswer(path, 1, "13") }, "wrong1: 12\npart1: 10\nwrong1: 13\n"},
+	}
+	for _, step := range steps {
+		if err := step.record(); err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := string(b); got != step.want {
+			t.Errorf("%s: file is %q, want %q", step.name, got, step.want)
+		}
+	}
+
+	a, err := ReadAnswers(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !a.IsWrong(1, "13") || a.IsWrong(1, "10") || a.IsWrong(2, "12") || a.IsWrong(3, "12") {
+		t.Errorf("IsWrong() doesn't match %+v", a)
+	}
+
+	// comments survive, and the result still parses
+	if err := os.WriteFile(path, []byte("# from the site\npart1: 1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := RecordAnswer(path, 2, "2"); err != nil {
+		t.Fatal(err)
+	}
+	a, err = ReadAnswers(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Part1 != "1" || a.Part2 != "2" {
+		t.Errorf("after recording, answers are %+v", a)
+	}
+}
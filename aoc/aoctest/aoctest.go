@@ -0,0 +1,114 @@
+// Package aoctest checks and benchmarks a day's solutions against its
+// recorded answers.
+package aoctest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kentquirk/aoc2024/aoc"
+)
+
+// Answers runs a day's parts against every input in the data directory that
+// has an answers file, with one subtest per input and part. It is meant to be
+// the whole of a day's answer test:
+//
+//	func TestAnswers(t *testing.T) {
+//		aoctest.Answers(t, 6)
+//	}
+func Answers(t *testing.T, day int) {
+	t.Helper()
+	s, ok := aoc.Lookup(day)
+	if !ok {
+		t.Fatalf("day %d is not registered", day)
+	}
+	names, err := aoc.AnswerInputs(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) == 0 {
+		t.Skip("no answers files in data")
+	}
+	for _, name := range names {
+		t.Run(name, func(t *testing.T) {
+			want, err := aoc.ReadAnswers(aoc.AnswersFile(name))
+			if err != nil {
+				t.Fatal(err)
+			}
+			in, err := aoc.ReadInput(name, want.Args...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tests := []struct {
+				name string
+				part int
+				f    func(*aoc.Input) (any, error)
+			}{
+				{"part1", 1, s.Part1},
+				{"part2", 2, s.Part2},
+			}
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					if reason, ok := want.Skipped(tt.part); ok {
+						t.Skip(reason)
+					}
+					wantAnswer, ok := want.Want(tt.part)
+					if !ok {
+						t.Skip("answer not known")
+					}
+					got, err := tt.f(in)
+					if err != nil {
+						t.Fatalf("day %d %s %s: %v", day, name, tt.name, err)
+					}
+					if gotAnswer := aoc.FormatAnswer(got); gotAnswer != wantAnswer {
+						t.Errorf("day %d %s %s:\n\tgot:  %s\n\twant: %s", day, name, tt.name, gotAnswer, wantAnswer)
+					}
+				})
+			}
+		})
+	}
+}
+
+// Benchmark runs a benchmark for each part with a known answer, on every
+// input that has an answers file. Parts without an answer are left out, since
+// they're the ones that don't finish or don't produce anything useful. The
+// solutions' own output is thrown away while they run.
+//
+//	func BenchmarkParts(b *testing.B) {
+//		aoctest.Benchmark(b, 6)
+//	}
+func Benchmark(b *testing.B, day int) {
+	b.Helper()
+	s, ok := aoc.Lookup(day)
+	if !ok {
+		b.Fatalf("day %d is not registered", day)
+	}
+	names, err := aoc.AnswerInputs(".")
+	if err != nil {
+		b.Fatal(err)
+	}
+	for _, name := range names {
+		want, err := aoc.ReadAnswers(aoc.AnswersFile(name))
+		if err != nil {
+			b.Fatal(err)
+		}
+		in, err := aoc.ReadInput(name, want.Args...)
+		if err != nil {
+			b.Fatal(err)
+		}
+		for part, f := range []func(*aoc.Input) (any, error){s.Part1, s.Part2} {
+			if _, ok := want.Want(part + 1); !ok {
+				continue
+			}
+			b.Run(fmt.Sprintf("%s/part%d", name, part+1), func(b *testing.B) {
+				defer aoc.Quiet()()
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					if _, err := f(in); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
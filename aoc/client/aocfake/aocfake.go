@@ -0,0 +1,170 @@
+// Package aocfake is a local stand-in for the Advent of Code site, for
+// testing the client and the commands built on it without the network.
+//
+// It serves the two endpoints the client uses, checks the session cookie,
+// and answers submissions with the same wording as the real site, including
+// the too-high/too-low hints and the lockout after a wrong answer.
+package aocfake
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Submission is an answer the server was sent.
+type Submission struct {
+	Day    int
+	Part   int
+	Answer string
+}
+
+// Server is a fake site for one year and one user. Set up inputs and answers
+// with SetInput and SetAnswer before pointing a client at URL.
+type Server struct {
+	*httptest.Server
+	Year    int
+	Session string
+	// Lockout is how long after a wrong answer the next one is refused.
+	Lockout time.Duration
+
+	mu          sync.Mutex
+	inputs      map[int]string
+	answers     map[[2]int]string
+	solved      map[[2]int]bool
+	lastWrong   time.Time
+	requests    int
+	submissions []Submission
+}
+
+// New starts a server. Close it when done.
+func New(year int, session string) *Server {
+	s := &Server{
+		Year:    year,
+		Session: session,
+		Lockout: time.Minute,
+		inputs:  make(map[int]string),
+		answers: make(map[[2]int]string),
+		solved:  make(map[[2]int]bool),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{year}/day/{day}/input", s.input)
+	mux.HandleFunc("POST /{year}/day/{day}/answer", s.answer)
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// SetInput sets a day's puzzle input.
+func (s *Server) SetInput(day int, input string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.inputs[day] = input
+}
+
+// SetAnswer sets the right answer for one part of a day.
+func (s *Server) SetAnswer(day, part int, answer string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.answers[[2]int{day, part}] = answer
+}
+
+// Requests returns how many requests the server has had.
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+// Submissions returns every answer the server has been sent, in order.
+func (s *Server) Submissions() []Submission {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Submission(nil), s.submissions...)
+}
+
+// day checks the session and the year and returns the day from the path. It
+// writes an error response and returns false if anything is wrong.
+func (s *Server) day(w http.ResponseWriter, r *http.Request) (int, bool) {
+	s.requests++
+	if c, err := r.Cookie("session"); err != nil || c.Value != s.Session {
+		http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+		return 0, false
+	}
+	day, err := strconv.Atoi(r.PathValue("day"))
+	if r.PathValue("year") != strconv.Itoa(s.Year) || err != nil {
+		http.NotFound(w, r)
+		return 0, false
+	}
+	if _, ok := s.inputs[day]; !ok {
+		http.Error(w, "Please don't repeatedly request this endpoint before it unlocks! The calendar countdown is synchronized with the server time; the link will be enabled on the calendar the instant this puzzle becomes available.", http.StatusNotFound)
+		return 0, false
+	}
+	return day, true
+}
+
+func (s *Server) input(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	day, ok := s.day(w, r)
+	if !ok {
+		return
+	}
+	fmt.Fprint(w, s.inputs[day])
+}
+
+func (s *Server) answer(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	day, ok := s.day(w, r)
+	if !ok {
+		return
+	}
+	part, err := strconv.Atoi(r.FormValue("level"))
+	if err != nil || part < 1 || part > 2 {
+		http.Error(w, "bad level", http.StatusBadRequest)
+		return
+	}
+	answer := r.FormValue("answer")
+	s.submissions = append(s.submissions, Submission{day, part, answer})
+
+	key := [2]int{day, part}
+	switch {
+	case s.solved[key] || (part == 2 && !s.solved[[2]int{day, 1}]):
+		page(w, "You don't seem to be solving the right level.  Did you already complete it? [Return to Day %d]", day)
+	case time.Since(s.lastWrong) < s.Lockout:
+		left := (s.Lockout - time.Since(s.lastWrong)).Round(time.Second)
+		page(w, "You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have %s left to wait. [Return to Day %d]", waitText(left), day)
+	case answer == s.answers[key]:
+		s.solved[key] = true
+		page(w, "That's the right answer!  You are one gold star closer to finding the Chief Historian. [Continue to Part Two]")
+	default:
+		s.lastWrong = time.Now()
+		hint := ""
+		got, err1 := strconv.Atoi(answer)
+		want, err2 := strconv.Atoi(s.answers[key])
+		if err1 == nil && err2 == nil {
+			hint = "too low"
+			if got > want {
+				hint = "too high"
+			}
+			hint = "; your answer is " + hint
+		}
+		page(w, "That's not the right answer%s.  If you're stuck, make sure you're using the full input data.  Please wait one minute before trying again. [Return to Day %d]", hint, day)
+	}
+}
+
+// waitText formats a duration the way the site does, like "1m 5s" or "30s".
+func waitText(d time.Duration) string {
+	m, sec := int(d/time.Minute), int(d%time.Minute/time.Second)
+	if m > 0 {
+		return fmt.Sprintf("%dm %ds", m, sec)
+	}
+	return fmt.Sprintf("%ds", sec)
+}
+
+func page(w http.ResponseWriter, format string, args ...any) {
+	fmt.Fprintf(w, "<!DOCTYPE html>\n<html lang=\"en-us\"><body><main>\n<article><p>%s</p></article>\n</main></body></html>\n", fmt.Sprintf(format, args...))
+}
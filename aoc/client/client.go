@@ -0,0 +1,206 @@
+// Package client fetches puzzle inputs from the Advent of Code site and
+// submits answers to it.
+//
+// The base URL is pluggable so it can be pointed at a local stand-in like
+// the one in aocfake; requests are spaced out so a loop over the days can't
+// hammer the real site.
+package client
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultURL is the real site.
+const DefaultURL = "https://adventofcode.com"
+
+// DefaultInterval is the least time the client leaves between requests.
+const DefaultInterval = 5 * time.Second
+
+// userAgent identifies the client, as the site asks automated tools to do.
+const userAgent = "github.com/kentquirk/aoc2024 aoc command"
+
+// Client talks to one year of the puzzles as one logged-in user.
+type Client struct {
+	BaseURL  string
+	Session  string // the value of the site's session cookie
+	Year     int
+	Interval time.Duration
+	HTTP     *http.Client
+
+	mu   sync.Mutex
+	last time.Time
+}
+
+// New returns a client with the default interval between requests.
+func New(baseURL, session string, year int) *Client {
+	return &Client{
+		BaseURL:  strings.TrimSuffix(baseURL, "/"),
+		Session:  session,
+		Year:     year,
+		Interval: DefaultInterval,
+		HTTP:     &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// ErrNoSession is returned when there's no session to log in with.
+var ErrNoSession = errors.New("no session token")
+
+// StatusError is a request the site turned down.
+type StatusError struct {
+	Status  int
+	Message string
+}
+
+func (e *StatusError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("server said %d %s", e.Status, http.StatusText(e.Status))
+	}
+	return fmt.Sprintf("server said %d: %s", e.Status, e.Message)
+}
+
+// Input returns a day's puzzle input.
+func (c *Client) Input(day int) (string, error) {
+	req, err := c.request(http.MethodGet, fmt.Sprintf("/%d/day/%d/input", c.Year, day), nil)
+	if err != nil {
+		return "", err
+	}
+	return c.do(req)
+}
+
+// Outcome is what the site made of a submitted answer.
+type Outcome int
+
+const (
+	Unknown Outcome = iota
+	Correct
+	Wrong
+	TooSoon       // the last wrong answer was too recent; see Verdict.Wait
+	AlreadySolved // that part has already been solved
+)
+
+func (o Outcome) String() string {
+	switch o {
+	case Correct:
+		return "correct"
+	case Wrong:
+		return "wrong"
+	case TooSoon:
+		return "too soon"
+	case AlreadySolved:
+		return "already solved"
+	}
+	return "unknown"
+}
+
+// Verdict is the response to a submitted answer.
+type Verdict struct {
+	Outcome Outcome
+	Hint    string        // "too high" or "too low", when the site says
+	Wait    time.Duration // how long to wait before trying again, when it says
+	Message string        // the text of the response
+}
+
+// Submit sends an answer for one part of a day.
+func (c *Client) Submit(day, part int, answer string) (*Verdict, error) {
+	form := url.Values{"level": {strconv.Itoa(part)}, "answer": {answer}}
+	req, err := c.request(http.MethodPost, fmt.Sprintf("/%d/day/%d/answer", c.Year, day), strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	body, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	return ParseVerdict(body), nil
+}
+
+func (c *Client) request(method, path string, body io.Reader) (*http.Request, error) {
+	if c.Session == "" {
+		return nil, ErrNoSession
+	}
+	req, err := http.NewRequest(method, c.BaseURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+	req.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+	req.Header.Set("User-Agent", userAgent)
+	return req, nil
+}
+
+func (c *Client) do(req *http.Request) (string, error) {
+	c.throttle()
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", &StatusError{Status: resp.StatusCode, Message: strings.TrimSpace(string(body))}
+	}
+	return string(body), nil
+}
+
+// throttle waits until at least Interval has passed since the last request.
+func (c *Client) throttle() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if wait := c.Interval - time.Since(c.last); !c.last.IsZero() && wait > 0 {
+		time.Sleep(wait)
+	}
+	c.last = time.Now()
+}
+
+var (
+	articleRE = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	tagRE     = regexp.MustCompile(`<[^>]*>`)
+	spaceRE   = regexp.MustCompile(`\s+`)
+	waitRE    = regexp.MustCompile(`You have (?:(\d+)m )?(\d+)s left to wait`)
+)
+
+// ParseVerdict reads the page the site returns after an answer is submitted.
+func ParseVerdict(page string) *Verdict {
+	text := page
+	if m := articleRE.FindStringSubmatch(page); m != nil {
+		text = m[1]
+	}
+	text = html.UnescapeString(tagRE.ReplaceAllString(text, ""))
+	text = strings.TrimSpace(spaceRE.ReplaceAllString(text, " "))
+
+	v := &Verdict{Message: text}
+	switch {
+	case strings.Contains(text, "That's the right answer"):
+		v.Outcome = Correct
+	case strings.Contains(text, "That's not the right answer"):
+		v.Outcome = Wrong
+		for _, hint := range []string{"too high", "too low"} {
+			if strings.Contains(text, "your answer is "+hint) {
+				v.Hint = hint
+			}
+		}
+	case strings.Contains(text, "You gave an answer too recently"):
+		v.Outcome = TooSoon
+	case strings.Contains(text, "You don't seem to be solving the right level"):
+		v.Outcome = AlreadySolved
+	}
+	if m := waitRE.FindStringSubmatch(text); m != nil {
+		mins, _ := strconv.Atoi(m[1])
+		secs, _ := strconv.Atoi(m[2])
+		v.Wait = time.Duration(mins)*time.Minute + time.Duration(secs)*time.Second
+	}
+	return v
+}
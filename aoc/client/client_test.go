@@ -0,0 +1,131 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/kentquirk/aoc2024/aoc/client/aocfake"
+)
+
+func fakeClient(t *testing.T) (*Client, *aocfake.Server) {
+	t.Helper()
+	s := aocfake.New(2024, "secret")
+	t.Cleanup(s.Close)
+	s.SetInput(1, "3   4\n4   3\n")
+	s.SetAnswer(1, 1, "11")
+	s.SetAnswer(1, 2, "31")
+	c := New(s.URL, "secret", 2024)
+	c.Interval = 0
+	return c, s
+}
+
+func TestInput(t *testing.T) {
+	c, _ := fakeClient(t)
+	got, err := c.Input(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "3   4\n4   3\n" {
+		t.Errorf("Input() = %q", got)
+	}
+
+	var se *StatusError
+	if _, err := c.Input(2); !errors.As(err, &se) || se.Status != http.StatusNotFound {
+		t.Errorf("Input() of a locked day = %v, want a 404", err)
+	}
+
+	c.Session = "wrong"
+	if _, err := c.Input(1); !errors.As(err, &se) || se.Status != http.StatusBadRequest {
+		t.Errorf("Input() with a bad session = %v, want a 400", err)
+	}
+	c.Session = ""
+	if _, err := c.Input(1); !errors.Is(err, ErrNoSession) {
+		t.Errorf("Input() with no session = %v, want ErrNoSession", err)
+	}
+}
+
+func TestSubmit(t *testing.T) {
+	c, s := fakeClient(t)
+	s.Lockout = time.Hour
+	tests := []struct {
+		name    string
+		part    int
+		answer  string
+		outcome Outcome
+		hint    string
+	}{
+		{"part 2 first", 2, "31", AlreadySolved, ""},
+		{"too high", 1, "12", Wrong, "too high"},
+		{"locked out", 1, "11", TooSoon, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := c.Submit(1, tt.part, tt.answer)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if v.Outcome != tt.outcome || v.Hint != tt.hint {
+				t.Errorf("Submit() = %v %q, want %v %q (%s)", v.Outcome, v.Hint, tt.outcome, tt.hint, v.Message)
+			}
+		})
+	}
+
+	s.Lockout = 0
+	for part, answer := range []string{"11", "31"} {
+		if v, err := c.Submit(1, part+1, answer); err != nil || v.Outcome != Correct {
+			t.Fatalf("Submit(part %d) = %v, %v", part+1, v, err)
+		}
+	}
+	if v, _ := c.Submit(1, 1, "11"); v.Outcome != AlreadySolved {
+		t.Errorf("resubmitting = %v, want %v", v.Outcome, AlreadySolved)
+	}
+	if got := len(s.Submissions()); got != 6 {
+		t.Errorf("server saw %d submissions, want 6", got)
+	}
+}
+
+func TestThrottle(t *testing.T) {
+	c, s := fakeClient(t)
+	c.Interval = 50 * time.Millisecond
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := c.Input(1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the first request goes straight away, the other two wait
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("3 requests took %v, want at least 100ms", elapsed)
+	}
+	if s.Requests() != 3 {
+		t.Errorf("server saw %d requests, want 3", s.Requests())
+	}
+}
+
+func TestParseVerdict(t *testing.T) {
+	tests := []struct {
+		name    string
+		page    string
+		outcome Outcome
+		hint    string
+		wait    time.Duration
+	}{
+		{"right", `<main><article><p>That's the right answer!  You are <em>one gold star</em> closer.</p></article></main>`, Correct, "", 0},
+		{"too low", `<article><p>That's not the right answer; your answer is too low.  Please wait one minute.</p></article>`, Wrong, "too low", 0},
+		{"no hint", `<article><p>That's not the right answer.  If you're stuck...</p></article>`, Wrong, "", 0},
+		{"wait minutes", `<article><p>You gave an answer too recently; you have to wait.  You have 4m 26s left to wait.</p></article>`, TooSoon, "", 4*time.Minute + 26*time.Second},
+		{"wait seconds", `<article><p>You gave an answer too recently.  You have 9s left to wait.</p></article>`, TooSoon, "", 9 * time.Second},
+		{"done", `<article><p>You don't seem to be solving the right level.  Did you already complete it?</p></article>`, AlreadySolved, "", 0},
+		{"something else", `<html>what?</html>`, Unknown, "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := ParseVerdict(tt.page)
+			if v.Outcome != tt.outcome || v.Hint != tt.hint || v.Wait != tt.wait {
+				t.Errorf("ParseVerdict() = %v %q %v, want %v %q %v", v.Outcome, v.Hint, v.Wait, tt.outcome, tt.hint, tt.wait)
+			}
+		})
+	}
+}
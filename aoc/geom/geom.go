@@ -0,0 +1,213 @@
+// Package geom has the points and directions that the grid puzzles move
+// around with.
+//
+// Points are (X, Y) with X the column and Y the row, so (0, 0) is the top
+// left corner and Y grows downward, the way the input reads. That makes Up
+// (0, -1), and turning right is clockwise on the screen.
+package geom
+
+import "fmt"
+
+// Point is a position, or the vector between two of them.
+type Point struct {
+	X int
+	Y int
+}
+
+// Origin is the zero Point.
+var Origin = Point{}
+
+func (p Point) String() string {
+	return fmt.Sprintf("(%d,%d)", p.X, p.Y)
+}
+
+// Add returns p moved by v.
+func (p Point) Add(v Point) Point {
+	return Point{p.X + v.X, p.Y + v.Y}
+}
+
+// Sub returns p - q.
+func (p Point) Sub(q Point) Point {
+	return Point{p.X - q.X, p.Y - q.Y}
+}
+
+// VectorTo returns the vector that takes p to q.
+func (p Point) VectorTo(q Point) Point {
+	return q.Sub(p)
+}
+
+// Scale returns p with both coordinates multiplied by k.
+func (p Point) Scale(k int) Point {
+	return Point{p.X * k, p.Y * k}
+}
+
+// Neg returns p pointing the other way.
+func (p Point) Neg() Point {
+	return Point{-p.X, -p.Y}
+}
+
+// RotateRight returns the vector p turned a quarter turn clockwise.
+func (p Point) RotateRight() Point {
+	return Point{-p.Y, p.X}
+}
+
+// RotateLeft returns the vector p turned a quarter turn counterclockwise.
+func (p Point) RotateLeft() Point {
+	return Point{p.Y, -p.X}
+}
+
+// Move returns the point one step from p in direction d.
+func (p Point) Move(d Dir4) Point {
+	return p.Add(d.Delta())
+}
+
+// Move8 returns the point one step from p in direction d.
+func (p Point) Move8(d Dir8) Point {
+	return p.Add(d.Delta())
+}
+
+// Manhattan returns the taxicab distance between p and q: the number of
+// orthogonal steps it takes to get from one to the other.
+func (p Point) Manhattan(q Point) int {
+	return abs(p.X-q.X) + abs(p.Y-q.Y)
+}
+
+// Chebyshev returns the king's-move distance between p and q, where a
+// diagonal step counts as one.
+func (p Point) Chebyshev(q Point) int {
+	return max(abs(p.X-q.X), abs(p.Y-q.Y))
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// Dir4 is one of the four orthogonal directions, in clockwise order from Up.
+type Dir4 uint8
+
+const (
+	Up Dir4 = iota
+	Right
+	Down
+	Left
+)
+
+// Dirs4 is every Dir4, clockwise from Up.
+var Dirs4 = [4]Dir4{Up, Right, Down, Left}
+
+var dir4Deltas = [4]Point{{0, -1}, {1, 0}, {0, 1}, {-1, 0}}
+var dir4Names = [4]string{"up", "right", "down", "left"}
+
+// glyphs are the arrow characters the puzzles use for each direction.
+const glyphs = "^>v<"
+
+// Delta returns the vector for one step in direction d.
+func (d Dir4) Delta() Point {
+	return dir4Deltas[d%4]
+}
+
+// TurnRight returns the direction a quarter turn clockwise from d.
+func (d Dir4) TurnRight() Dir4 {
+	return (d + 1) % 4
+}
+
+// TurnLeft returns the direction a quarter turn counterclockwise from d.
+func (d Dir4) TurnLeft() Dir4 {
+	return (d + 3) % 4
+}
+
+// Opposite returns the direction pointing back the way d came.
+func (d Dir4) Opposite() Dir4 {
+	return (d + 2) % 4
+}
+
+// Glyph returns the arrow that the puzzles draw for d: one of ^ > v <.
+func (d Dir4) Glyph() byte {
+	return glyphs[d%4]
+}
+
+// Dir8 returns the same direction as a Dir8.
+func (d Dir4) Dir8() Dir8 {
+	return Dir8(d%4) * 2
+}
+
+func (d Dir4) String() string {
+	if d > Left {
+		return fmt.Sprintf("Dir4(%d)", uint8(d))
+	}
+	return dir4Names[d]
+}
+
+// ParseDir4 turns an arrow (^ > v <), a letter for up, right, down or left
+// (U R D L), or a compass letter (N E S W) into a direction.
+func ParseDir4(c byte) (Dir4, bool) {
+	switch c {
+	case '^', 'U', 'N':
+		return Up, true
+	case '>', 'R', 'E':
+		return Right, true
+	case 'v', 'D', 'S':
+		return Down, true
+	case '<', 'L', 'W':
+		return Left, true
+	}
+	return 0, false
+}
+
+// Dir8 is one of the eight compass directions, in clockwise order from N.
+type Dir8 uint8
+
+const (
+	N Dir8 = iota
+	NE
+	E
+	SE
+	S
+	SW
+	W
+	NW
+)
+
+// Dirs8 is every Dir8, clockwise from N.
+var Dirs8 = [8]Dir8{N, NE, E, SE, S, SW, W, NW}
+
+var dir8Deltas = [8]Point{{0, -1}, {1, -1}, {1, 0}, {1, 1}, {0, 1}, {-1, 1}, {-1, 0}, {-1, -1}}
+var dir8Names = [8]string{"N", "NE", "E", "SE", "S", "SW", "W", "NW"}
+
+// Delta returns the vector for one step in direction d.
+func (d Dir8) Delta() Point {
+	return dir8Deltas[d%8]
+}
+
+// TurnRight returns the direction an eighth of a turn clockwise from d.
+func (d Dir8) TurnRight() Dir8 {
+	return (d + 1) % 8
+}
+
+// TurnLeft returns the direction an eighth of a turn counterclockwise from d.
+func (d Dir8) TurnLeft() Dir8 {
+	return (d + 7) % 8
+}
+
+// Opposite returns the direction pointing back the way d came.
+func (d Dir8) Opposite() Dir8 {
+	return (d + 4) % 8
+}
+
+// Dir4 returns d as a Dir4, or false if d is a diagonal.
+func (d Dir8) Dir4() (Dir4, bool) {
+	if d%2 != 0 {
+		return 0, false
+	}
+	return Dir4(d%8) / 2, true
+}
+
+func (d Dir8) String() string {
+	if d > NW {
+		return fmt.Sprintf("Dir8(%d)", uint8(d))
+	}
+	return dir8Names[d]
+}
@@ -0,0 +1,226 @@
+package geom
+
+import "testing"
+
+func TestDir4(t *testing.T) {
+	tests := []struct {
+		d        Dir4
+		delta    Point
+		glyph    byte
+		name     string
+		right    Dir4
+		left     Dir4
+		opposite Dir4
+		dir8     Dir8
+	}{
+		{Up, Point{0, -1}, '^', "up", Right, Left, Down, N},
+		{Right, Point{1, 0}, '>', "right", Down, Up, Left, E},
+		{Down, Point{0, 1}, 'v', "down", Left, Right, Up, S},
+		{Left, Point{-1, 0}, '<', "left", Up, Down, Right, W},
+	}
+	if len(tests) != len(Dirs4) {
+		t.Fatalf("%d tests for %d directions", len(tests), len(Dirs4))
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Dirs4[i] != tt.d {
+				t.Errorf("Dirs4[%d] = %v, want %v", i, Dirs4[i], tt.d)
+			}
+			if got := tt.d.Delta(); got != tt.delta {
+				t.Errorf("Delta() = %v, want %v", got, tt.delta)
+			}
+			if got := tt.d.Glyph(); got != tt.glyph {
+				t.Errorf("Glyph() = %q, want %q", got, tt.glyph)
+			}
+			if got := tt.d.String(); got != tt.name {
+				t.Errorf("String() = %q, want %q", got, tt.name)
+			}
+			if got := tt.d.TurnRight(); got != tt.right {
+				t.Errorf("TurnRight() = %v, want %v", got, tt.right)
+			}
+			if got := tt.d.TurnLeft(); got != tt.left {
+				t.Errorf("TurnLeft() = %v, want %v", got, tt.left)
+			}
+			if got := tt.d.Opposite(); got != tt.opposite {
+				t.Errorf("Opposite() = %v, want %v", got, tt.opposite)
+			}
+			if got := tt.d.Dir8(); got != tt.dir8 {
+				t.Errorf("Dir8() = %v, want %v", got, tt.dir8)
+			}
+			if back, ok := tt.dir8.Dir4(); !ok || back != tt.d {
+				t.Errorf("%v.Dir4() = %v, %v, want %v", tt.dir8, back, ok, tt.d)
+			}
+
+			// the turns have to agree with the vector arithmetic
+			if got, want := tt.d.TurnRight().Delta(), tt.delta.RotateRight(); got != want {
+				t.Errorf("TurnRight().Delta() = %v, but Delta().RotateRight() = %v", got, want)
+			}
+			if got, want := tt.d.TurnLeft().Delta(), tt.delta.RotateLeft(); got != want {
+				t.Errorf("TurnLeft().Delta() = %v, but Delta().RotateLeft() = %v", got, want)
+			}
+			if got, want := tt.d.Opposite().Delta(), tt.delta.Neg(); got != want {
+				t.Errorf("Opposite().Delta() = %v, want %v", got, want)
+			}
+			if got := tt.d.TurnRight().TurnLeft(); got != tt.d {
+				t.Errorf("TurnRight().TurnLeft() = %v", got)
+			}
+			if got := tt.d.TurnRight().TurnRight(); got != tt.opposite {
+				t.Errorf("two right turns = %v, want %v", got, tt.opposite)
+			}
+			if got, want := Origin.Move(tt.d), tt.delta; got != want {
+				t.Errorf("Move() = %v, want %v", got, want)
+			}
+
+			// and the glyph has to point the way the direction moves
+			if got, ok := ParseDir4(tt.glyph); !ok || got != tt.d {
+				t.Errorf("ParseDir4(%q) = %v, %v, want %v", tt.glyph, got, ok, tt.d)
+			}
+		})
+	}
+}
+
+func TestParseDir4(t *testing.T) {
+	tests := []struct {
+		c      byte
+		want   Dir4
+		wantOK bool
+	}{
+		{'^', Up, true}, {'U', Up, true}, {'N', Up, true},
+		{'>', Right, true}, {'R', Right, true}, {'E', Right, true},
+		{'v', Down, true}, {'D', Down, true}, {'S', Down, true},
+		{'<', Left, true}, {'L', Left, true}, {'W', Left, true},
+		{'.', 0, false}, {'#', 0, false}, {'V', 0, false}, {0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.c), func(t *testing.T) {
+			got, ok := ParseDir4(tt.c)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("ParseDir4(%q) = %v, %v, want %v, %v", tt.c, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestDir8(t *testing.T) {
+	tests := []struct {
+		d     Dir8
+		delta Point
+		name  string
+	}{
+		{N, Point{0, -1}, "N"},
+		{NE, Point{1, -1}, "NE"},
+		{E, Point{1, 0}, "E"},
+		{SE, Point{1, 1}, "SE"},
+		{S, Point{0, 1}, "S"},
+		{SW, Point{-1, 1}, "SW"},
+		{W, Point{-1, 0}, "W"},
+		{NW, Point{-1, -1}, "NW"},
+	}
+	if len(tests) != len(Dirs8) {
+		t.Fatalf("%d tests for %d directions", len(tests), len(Dirs8))
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Dirs8[i] != tt.d {
+				t.Errorf("Dirs8[%d] = %v, want %v", i, Dirs8[i], tt.d)
+			}
+			if got := tt.d.Delta(); got != tt.delta {
+				t.Errorf("Delta() = %v, want %v", got, tt.delta)
+			}
+			if got := tt.d.String(); got != tt.name {
+				t.Errorf("String() = %q, want %q", got, tt.name)
+			}
+			next := tests[(i+1)%len(tests)].d
+			if got := tt.d.TurnRight(); got != next {
+				t.Errorf("TurnRight() = %v, want %v", got, next)
+			}
+			if got := next.TurnLeft(); got != tt.d {
+				t.Errorf("%v.TurnLeft() = %v, want %v", next, got, tt.d)
+			}
+			if got, want := tt.d.Opposite().Delta(), tt.delta.Neg(); got != want {
+				t.Errorf("Opposite().Delta() = %v, want %v", got, want)
+			}
+			if got, want := tt.d.TurnRight().TurnRight().Delta(), tt.delta.RotateRight(); got != want {
+				t.Errorf("two right turns = %v, want %v", got, want)
+			}
+			if d4, ok := tt.d.Dir4(); ok != (i%2 == 0) {
+				t.Errorf("Dir4() = %v, %v; diagonals shouldn't convert", d4, ok)
+			} else if ok && d4.Delta() != tt.delta {
+				t.Errorf("Dir4().Delta() = %v, want %v", d4.Delta(), tt.delta)
+			}
+			if got := Origin.Chebyshev(tt.delta); got != 1 {
+				t.Errorf("Chebyshev step = %d, want 1", got)
+			}
+		})
+	}
+}
+
+func TestDirStringOutOfRange(t *testing.T) {
+	if got := Dir4(9).String(); got != "Dir4(9)" {
+		t.Errorf("Dir4(9).String() = %q", got)
+	}
+	if got := Dir8(9).String(); got != "Dir8(9)" {
+		t.Errorf("Dir8(9).String() = %q", got)
+	}
+}
+
+func TestPoint(t *testing.T) {
+	p, q := Point{2, 3}, Point{-1, 7}
+	tests := []struct {
+		name string
+		got  Point
+		want Point
+	}{
+		{"add", p.Add(q), Point{1, 10}},
+		{"sub", p.Sub(q), Point{3, -4}},
+		{"vector to", p.VectorTo(q), Point{-3, 4}},
+		{"vector gets there", p.Add(p.VectorTo(q)), q},
+		{"scale", p.Scale(3), Point{6, 9}},
+		{"scale negative", p.Scale(-1), p.Neg()},
+		{"rotate right", p.RotateRight(), Point{-3, 2}},
+		{"rotate left", p.RotateLeft(), Point{3, -2}},
+		{"four rights", p.RotateRight().RotateRight().RotateRight().RotateRight(), p},
+		{"move", p.Move(Left), Point{1, 3}},
+		{"move diagonally", p.Move8(SE), Point{3, 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		name      string
+		p, q      Point
+		manhattan int
+		chebyshev int
+	}{
+		{"same", Point{4, 4}, Point{4, 4}, 0, 0},
+		{"orthogonal", Point{0, 0}, Point{0, -5}, 5, 5},
+		{"diagonal", Point{0, 0}, Point{3, 3}, 6, 3},
+		{"mixed signs", Point{-2, 5}, Point{3, 1}, 9, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.Manhattan(tt.q); got != tt.manhattan {
+				t.Errorf("Manhattan() = %d, want %d", got, tt.manhattan)
+			}
+			if got := tt.q.Manhattan(tt.p); got != tt.manhattan {
+				t.Errorf("Manhattan() reversed = %d, want %d", got, tt.manhattan)
+			}
+			if got := tt.p.Chebyshev(tt.q); got != tt.chebyshev {
+				t.Errorf("Chebyshev() = %d, want %d", got, tt.chebyshev)
+			}
+		})
+	}
+}
+
+func TestPointString(t *testing.T) {
+	if got := (Point{3, -1}).String(); got != "(3,-1)" {
+		t.Errorf("String() = %q", got)
+	}
+}
@@ -0,0 +1,214 @@
+// Package grid is a rectangular 2D grid of cells, which is what most of the
+// puzzles turn out to be.
+//
+// Points are geom.Points: (X, Y) with X the column and Y the row, so (0, 0)
+// is the top left corner and Y grows downward, the way the input reads.
+package grid
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+
+	"github.com/kentquirk/aoc2024/aoc/geom"
+)
+
+// Point is a cell position. It's the same as geom.Point, so the directions
+// there work for moving around the grid.
+type Point = geom.Point
+
+var (
+	orthogonal = deltas(geom.Dirs4[:])
+	all8       = deltas(geom.Dirs8[:])
+)
+
+func deltas[D interface{ Delta() Point }](dirs []D) []Point {
+	d := make([]Point, len(dirs))
+	for i, dir := range dirs {
+		d[i] = dir.Delta()
+	}
+	return d
+}
+
+// Grid is a Width x Height grid of T.
+type Grid[T any] struct {
+	Width  int
+	Height int
+	cells  []T
+}
+
+// New returns a grid of the given size, filled with the zero value of T.
+func New[T any](width, height int) *Grid[T] {
+	return &Grid[T]{Width: width, Height: height, cells: make([]T, width*height)}
+}
+
+// Parse builds a grid from lines of text, using cell to turn each byte into
+// a T. All the lines must be the same length.
+func Parse[T any](lines []string, cell func(p Point, b byte) T) (*Grid[T], error) {
+	if len(lines) == 0 {
+		return New[T](0, 0), nil
+	}
+	g := New[T](len(lines[0]), len(lines))
+	for y, line := range lines {
+		if len(line) != g.Width {
+			return nil, fmt.Errorf("line %d is %d long, want %d", y+1, len(line), g.Width)
+		}
+		for x := 0; x < len(line); x++ {
+			p := Point{X: x, Y: y}
+			g.cells[g.index(p)] = cell(p, line[x])
+		}
+	}
+	return g, nil
+}
+
+// Bytes builds a grid of the characters in lines.
+func Bytes(lines []string) (*Grid[byte], error) {
+	return Parse(lines, func(_ Point, b byte) byte { return b })
+}
+
+func (g *Grid[T]) index(p Point) int {
+	return p.Y*g.Width + p.X
+}
+
+// In reports whether p is on the grid.
+func (g *Grid[T]) In(p Point) bool {
+	return p.X >= 0 && p.X < g.Width && p.Y >= 0 && p.Y < g.Height
+}
+
+// Get returns the cell at p, or false if p is off the grid.
+func (g *Grid[T]) Get(p Point) (T, bool) {
+	if !g.In(p) {
+		var zero T
+		return zero, false
+	}
+	return g.cells[g.index(p)], true
+}
+
+// At returns the cell at p, or the zero value of T if p is off the grid.
+func (g *Grid[T]) At(p Point) T {
+	v, _ := g.Get(p)
+	return v
+}
+
+// Set changes the cell at p. It returns false and does nothing if p is off
+// the grid.
+func (g *Grid[T]) Set(p Point, v T) bool {
+	if !g.In(p) {
+		return false
+	}
+	g.cells[g.index(p)] = v
+	return true
+}
+
+// All iterates over every cell, row by row.
+func (g *Grid[T]) All() iter.Seq2[Point, T] {
+	return func(yield func(Point, T) bool) {
+		for i, v := range g.cells {
+			if !yield(Point{X: i % g.Width, Y: i / g.Width}, v) {
+				return
+			}
+		}
+	}
+}
+
+// Neighbors iterates over the cells at p plus each of the offsets that are
+// on the grid.
+func (g *Grid[T]) Neighbors(p Point, offsets []Point) iter.Seq2[Point, T] {
+	return func(yield func(Point, T) bool) {
+		for _, d := range offsets {
+			n := p.Add(d)
+			if v, ok := g.Get(n); ok && !yield(n, v) {
+				return
+			}
+		}
+	}
+}
+
+// Neighbors4 iterates over the up to four orthogonal neighbors of p.
+func (g *Grid[T]) Neighbors4(p Point) iter.Seq2[Point, T] {
+	return g.Neighbors(p, orthogonal)
+}
+
+// Neighbors8 iterates over the up to eight neighbors of p, diagonals included.
+func (g *Grid[T]) Neighbors8(p Point) iter.Seq2[Point, T] {
+	return g.Neighbors(p, all8)
+}
+
+// FindFunc returns the points of every cell for which match is true, row by
+// row.
+func (g *Grid[T]) FindFunc(match func(T) bool) []Point {
+	var found []Point
+	for p, v := range g.All() {
+		if match(v) {
+			found = append(found, p)
+		}
+	}
+	return found
+}
+
+// Find returns the points of every cell equal to v, row by row, so
+// Find(g, 'S') finds the start of a maze.
+func Find[T comparable](g *Grid[T], v T) []Point {
+	return g.FindFunc(func(c T) bool { return c == v })
+}
+
+// Clone returns a copy of the grid.
+func (g *Grid[T]) Clone() *Grid[T] {
+	c := New[T](g.Width, g.Height)
+	copy(c.cells, g.cells)
+	return c
+}
+
+// Transpose returns a copy of the grid flipped over its main diagonal, so
+// rows become columns.
+func (g *Grid[T]) Transpose() *Grid[T] {
+	return g.remap(g.Height, g.Width, func(p Point) Point { return Point{X: p.Y, Y: p.X} })
+}
+
+// RotateRight returns a copy of the grid turned a quarter turn clockwise.
+func (g *Grid[T]) RotateRight() *Grid[T] {
+	return g.remap(g.Height, g.Width, func(p Point) Point { return Point{X: g.Height - 1 - p.Y, Y: p.X} })
+}
+
+// RotateLeft returns a copy of the grid turned a quarter turn
+// counterclockwise.
+func (g *Grid[T]) RotateLeft() *Grid[T] {
+	return g.remap(g.Height, g.Width, func(p Point) Point { return Point{X: p.Y, Y: g.Width - 1 - p.X} })
+}
+
+// remap copies every cell at p into a new width x height grid at to(p).
+func (g *Grid[T]) remap(width, height int, to func(Point) Point) *Grid[T] {
+	r := New[T](width, height)
+	for p, v := range g.All() {
+		r.Set(to(p), v)
+	}
+	return r
+}
+
+// Format renders the grid one row per line, using cell to draw each cell.
+func (g *Grid[T]) Format(cell func(p Point, v T) string) string {
+	var sb strings.Builder
+	for p, v := range g.All() {
+		sb.WriteString(cell(p, v))
+		if p.X == g.Width-1 {
+			sb.WriteByte('\n')
+		}
+	}
+	return sb.String()
+}
+
+// String renders the grid one row per line. Bytes and runes are drawn as
+// characters and anything else with fmt.Sprint, so it's mostly useful for
+// grids of characters; use Format for anything fancier.
+func (g *Grid[T]) String() string {
+	return g.Format(func(_ Point, v T) string {
+		switch c := any(v).(type) {
+		case byte:
+			return string(rune(c))
+		case rune:
+			return string(c)
+		default:
+			return fmt.Sprint(v)
+		}
+	})
+}
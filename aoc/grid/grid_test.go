@@ -0,0 +1,138 @@
+package grid
+
+import (
+	"maps"
+	"reflect"
+	"testing"
+)
+
+func mustBytes(t *testing.T, lines ...string) *Grid[byte] {
+	t.Helper()
+	g, err := Bytes(lines)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestParse(t *testing.T) {
+	g, err := Parse([]string{"12", "34", "56"}, func(_ Point, b byte) int { return int(b - '0') })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Width != 2 || g.Height != 3 {
+		t.Errorf("size = %dx%d, want 2x3", g.Width, g.Height)
+	}
+	if got := g.At(Point{X: 1, Y: 2}); got != 6 {
+		t.Errorf("At(1,2) = %d, want 6", got)
+	}
+	if _, err := Bytes([]string{"ab", "c"}); err == nil {
+		t.Error("ragged lines should be an error")
+	}
+	if g, err := Bytes(nil); err != nil || g.Width != 0 || g.Height != 0 {
+		t.Errorf("empty grid = %v, %v", g, err)
+	}
+}
+
+func TestGetSet(t *testing.T) {
+	g := mustBytes(t, "ab", "cd")
+	tests := []struct {
+		name   string
+		p      Point
+		want   byte
+		wantOK bool
+	}{
+		{"top left", Point{X: 0, Y: 0}, 'a', true},
+		{"bottom right", Point{X: 1, Y: 1}, 'd', true},
+		{"x is column", Point{X: 1, Y: 0}, 'b', true},
+		{"left of grid", Point{X: -1, Y: 0}, 0, false},
+		{"below grid", Point{X: 0, Y: 2}, 0, false},
+		{"right of grid", Point{X: 2, Y: 1}, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := g.Get(tt.p)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("Get(%v) = %q, %v, want %q, %v", tt.p, got, ok, tt.want, tt.wantOK)
+			}
+			if set := g.Clone().Set(tt.p, 'x'); set != tt.wantOK {
+				t.Errorf("Set(%v) = %v, want %v", tt.p, set, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestNeighbors(t *testing.T) {
+	g := mustBytes(t, "abc", "def", "ghi")
+	tests := []struct {
+		name string
+		got  map[Point]byte
+		want map[Point]byte
+	}{
+		{"4 in middle", maps.Collect(g.Neighbors4(Point{X: 1, Y: 1})),
+			map[Point]byte{{X: 1, Y: 0}: 'b', {X: 2, Y: 1}: 'f', {X: 1, Y: 2}: 'h', {X: 0, Y: 1}: 'd'}},
+		{"4 in corner", maps.Collect(g.Neighbors4(Point{X: 0, Y: 0})),
+			map[Point]byte{{X: 1, Y: 0}: 'b', {X: 0, Y: 1}: 'd'}},
+		{"8 in corner", maps.Collect(g.Neighbors8(Point{X: 2, Y: 2})),
+			map[Point]byte{{X: 2, Y: 1}: 'f', {X: 1, Y: 2}: 'h', {X: 1, Y: 1}: 'e'}},
+		{"8 in middle", maps.Collect(g.Neighbors8(Point{X: 1, Y: 1})),
+			map[Point]byte{{X: 0, Y: 0}: 'a', {X: 1, Y: 0}: 'b', {X: 2, Y: 0}: 'c', {X: 0, Y: 1}: 'd', {X: 2, Y: 1}: 'f', {X: 0, Y: 2}: 'g', {X: 1, Y: 2}: 'h', {X: 2, Y: 2}: 'i'}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFind(t *testing.T) {
+	g := mustBytes(t, "S.#", "#.S")
+	if got, want := Find(g, 'S'), []Point{{X: 0, Y: 0}, {X: 2, Y: 1}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Find() = %v, want %v", got, want)
+	}
+	if got := Find(g, 'E'); got != nil {
+		t.Errorf("Find() = %v, want nothing", got)
+	}
+}
+
+func TestReshape(t *testing.T) {
+	g := mustBytes(t, "abc", "def")
+	tests := []struct {
+		name string
+		got  *Grid[byte]
+		want string
+	}{
+		{"transpose", g.Transpose(), "ad\nbe\ncf\n"},
+		{"rotate right", g.RotateRight(), "da\neb\nfc\n"},
+		{"rotate left", g.RotateLeft(), "cf\nbe\nad\n"},
+		{"right then left", g.RotateRight().RotateLeft(), "abc\ndef\n"},
+		{"four rights", g.RotateRight().RotateRight().RotateRight().RotateRight(), "abc\ndef\n"},
+		{"transpose twice", g.Transpose().Transpose(), "abc\ndef\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got.String(); got != tt.want {
+				t.Errorf("got\n%swant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	g := New[int](3, 2)
+	g.Set(Point{X: 2, Y: 0}, 7)
+	if got, want := g.String(), "007\n000\n"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	got := g.Format(func(p Point, v int) string {
+		if v != 0 {
+			return "#"
+		}
+		return "."
+	})
+	if want := "..#\n...\n"; got != want {
+		t.Errorf("Format() = %q, want %q", got, want)
+	}
+}
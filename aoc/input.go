@@ -0,0 +1,140 @@
+// Package aoc holds the pieces shared by all of the daily solutions.
+package aoc
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Stdin is the input name that reads from standard input instead of a file.
+const Stdin = "-"
+
+// DataFile returns the path of a named input in a day's data directory,
+// so "sample" becomes "data/sample.txt".
+func DataFile(name string) string {
+	return filepath.Join("data", name+".txt")
+}
+
+// Open returns a reader for the named input. The name "-" means stdin;
+// anything else is looked up with DataFile relative to the current directory.
+func Open(name string) (io.ReadCloser, error) {
+	if name == Stdin {
+		return io.NopCloser(os.Stdin), nil
+	}
+	return os.Open(DataFile(name))
+}
+
+// Text reads all of r and returns it as a string, with CRLF line endings
+// converted to LF and a single trailing line ending removed. It is always
+// equal to strings.Join(lines, "\n") for the lines returned by Lines.
+func Text(r io.Reader) (string, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	b = bytes.ReplaceAll(b, []byte("\r\n"), []byte("\n"))
+	b = bytes.TrimSuffix(b, []byte("\n"))
+	return string(b), nil
+}
+
+// Lines reads all of r and splits it into lines. Line endings may be either
+// LF or CRLF, and a line ending at the end of the input does not produce an
+// extra empty line. Empty input returns no lines.
+func Lines(r io.Reader) ([]string, error) {
+	text, err := Text(r)
+	if err != nil {
+		return nil, err
+	}
+	if text == "" {
+		return []string{}, nil
+	}
+	return strings.Split(text, "\n"), nil
+}
+
+// Paragraphs reads r and returns its lines grouped into blocks separated by
+// one or more blank lines.
+func Paragraphs(r io.Reader) ([][]string, error) {
+	lines, err := Lines(r)
+	if err != nil {
+		return nil, err
+	}
+	return SplitParagraphs(lines), nil
+}
+
+// SplitParagraphs groups lines into blocks separated by blank lines. Runs of
+// blank lines, and blank lines at either end, never produce empty blocks.
+func SplitParagraphs(lines []string) [][]string {
+	paragraphs := make([][]string, 0)
+	start := -1
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			if start >= 0 {
+				paragraphs = append(paragraphs, lines[start:i])
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		paragraphs = append(paragraphs, lines[start:])
+	}
+	return paragraphs
+}
+
+// Grid reads r as a rectangular block of characters, one row per line.
+// It returns an error if the rows are not all the same width.
+func Grid(r io.Reader) ([][]byte, error) {
+	lines, err := Lines(r)
+	if err != nil {
+		return nil, err
+	}
+	grid := make([][]byte, len(lines))
+	for i, line := range lines {
+		if len(line) != len(lines[0]) {
+			return nil, fmt.Errorf("grid line %d has width %d, expected %d", i+1, len(line), len(lines[0]))
+		}
+		grid[i] = []byte(line)
+	}
+	return grid, nil
+}
+
+// ReadText reads the named input with Text.
+func ReadText(name string) (string, error) {
+	return read(name, Text)
+}
+
+// ReadLines reads the named input with Lines.
+func ReadLines(name string) ([]string, error) {
+	return read(name, Lines)
+}
+
+// ReadParagraphs reads the named input with Paragraphs.
+func ReadParagraphs(name string) ([][]string, error) {
+	return read(name, Paragraphs)
+}
+
+// ReadGrid reads the named input with Grid.
+func ReadGrid(name string) ([][]byte, error) {
+	return read(name, Grid)
+}
+
+func read[T any](name string, f func(io.Reader) (T, error)) (T, error) {
+	var zero T
+	r, err := Open(name)
+	if err != nil {
+		return zero, err
+	}
+	defer r.Close()
+	v, err := f(r)
+	if err != nil {
+		return zero, fmt.Errorf("%s: %w", name, err)
+	}
+	return v, nil
+}
@@ -0,0 +1,74 @@
+package aoc
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLines(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"empty", "", []string{}},
+		{"one line", "abc", []string{"abc"}},
+		{"trailing newline", "abc\ndef\n", []string{"abc", "def"}},
+		{"no trailing newline", "abc\ndef", []string{"abc", "def"}},
+		{"crlf", "abc\r\ndef\r\n", []string{"abc", "def"}},
+		{"blank line kept", "abc\n\ndef\n", []string{"abc", "", "def"}},
+		{"only newline", "\n", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Lines(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("Lines() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Lines() = %q, want %q", got, tt.want)
+			}
+			text, _ := Text(strings.NewReader(tt.input))
+			if joined := strings.Join(got, "\n"); joined != text {
+				t.Errorf("Text() = %q, want %q", text, joined)
+			}
+		})
+	}
+}
+
+func TestSplitParagraphs(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		want  [][]string
+	}{
+		{"empty", []string{}, [][]string{}},
+		{"one block", []string{"a", "b"}, [][]string{{"a", "b"}}},
+		{"two blocks", []string{"a", "b", "", "c"}, [][]string{{"a", "b"}, {"c"}}},
+		{"extra blanks", []string{"", "a", "", "", "b", ""}, [][]string{{"a"}, {"b"}}},
+		{"whitespace line", []string{"a", "  ", "b"}, [][]string{{"a"}, {"b"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SplitParagraphs(tt.lines); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SplitParagraphs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGrid(t *testing.T) {
+	got, err := Grid(strings.NewReader("ab\r\ncd\r\n"))
+	if err != nil {
+		t.Fatalf("Grid() error = %v", err)
+	}
+	want := [][]byte{[]byte("ab"), []byte("cd")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Grid() = %q, want %q", got, want)
+	}
+
+	if _, err := Grid(strings.NewReader("ab\nc\n")); err == nil {
+		t.Errorf("Grid() on ragged input should fail")
+	}
+}
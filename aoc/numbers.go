@@ -0,0 +1,158 @@
+package aoc
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// An Extractor pulls integers out of free-form text such as
+// "p=0,4 v=3,-3" or "Button A: X+94, Y+34". The zero value is ready to use.
+//
+// In signed mode (the default) a '-' immediately before a digit is a minus
+// sign unless it directly follows another digit, so "3,-3" is 3 and -3 while
+// "10-20" is 10 and 20. A '-' with no digit after it is never part of a number.
+type Extractor struct {
+	// Unsigned treats every '-' as a separator, so only digit runs are numbers.
+	Unsigned bool
+	// Strict makes extraction fail with a *NumberError on numbers that don't
+	// fit the result type, or that run straight into a letter or a decimal
+	// point (like "12ab" or "1.5"). Otherwise out-of-range values are clamped
+	// the way strconv clamps them, and trailing junk is ignored.
+	Strict bool
+}
+
+// ErrMalformed is wrapped by a NumberError for a number with trailing junk.
+var ErrMalformed = errors.New("malformed number")
+
+// A NumberError describes a number that a strict Extractor rejected.
+type NumberError struct {
+	Line int // 1-based line number, or 0 if the text was a single string
+	Col  int // 1-based byte column where the number starts
+	Text string
+	Err  error
+}
+
+func (e *NumberError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("line %d, column %d: %q: %v", e.Line, e.Col, e.Text, e.Err)
+	}
+	return fmt.Sprintf("column %d: %q: %v", e.Col, e.Text, e.Err)
+}
+
+func (e *NumberError) Unwrap() error {
+	return e.Err
+}
+
+// Ints returns every signed integer in s. It is shorthand for a zero Extractor
+// and never fails.
+func Ints(s string) []int {
+	v, _ := Extractor{}.Ints(s)
+	return v
+}
+
+// UnsignedInts returns every run of digits in s, ignoring any minus signs.
+func UnsignedInts(s string) []int {
+	v, _ := Extractor{Unsigned: true}.Ints(s)
+	return v
+}
+
+// Ints returns the integers in s as ints.
+func (x Extractor) Ints(s string) ([]int, error) {
+	return extract(x, s, 0, func(t string) (int, error) {
+		return strconv.Atoi(t)
+	})
+}
+
+// Int64s returns the integers in s as int64s, which matters on 32-bit
+// platforms where int is too small for some puzzle answers.
+func (x Extractor) Int64s(s string) ([]int64, error) {
+	return extract(x, s, 0, func(t string) (int64, error) {
+		return strconv.ParseInt(t, 10, 64)
+	})
+}
+
+// BigInts returns the integers in s with no limit on their size.
+func (x Extractor) BigInts(s string) ([]*big.Int, error) {
+	return extract(x, s, 0, func(t string) (*big.Int, error) {
+		n, ok := new(big.Int).SetString(t, 10)
+		if !ok {
+			return nil, strconv.ErrSyntax
+		}
+		return n, nil
+	})
+}
+
+// IntsInLines extracts the integers from each line separately, so that a
+// strict error can report the line it came from.
+func (x Extractor) IntsInLines(lines []string) ([][]int, error) {
+	result := make([][]int, len(lines))
+	for i, line := range lines {
+		numbers, err := extract(x, line, i+1, func(t string) (int, error) {
+			return strconv.Atoi(t)
+		})
+		if err != nil {
+			return nil, err
+		}
+		result[i] = numbers
+	}
+	return result, nil
+}
+
+func extract[T any](x Extractor, s string, line int, parse func(string) (T, error)) ([]T, error) {
+	numbers := make([]T, 0)
+	for i := 0; i < len(s); {
+		start, end := x.next(s, i)
+		if start < 0 {
+			break
+		}
+		i = end
+		v, err := parse(s[start:end])
+		if x.Strict {
+			if err == nil && end < len(s) && (isLetter(s[end]) || (s[end] == '.' && end+1 < len(s) && isDigit(s[end+1]))) {
+				err = ErrMalformed
+				for end < len(s) && (isLetter(s[end]) || isDigit(s[end]) || s[end] == '.') {
+					end++
+				}
+			}
+			if err != nil {
+				if ne, ok := err.(*strconv.NumError); ok {
+					err = ne.Err
+				}
+				return nil, &NumberError{Line: line, Col: start + 1, Text: s[start:end], Err: err}
+			}
+		}
+		numbers = append(numbers, v)
+	}
+	return numbers, nil
+}
+
+// next finds the first number at or after offset i and returns its span,
+// or -1 if there isn't one.
+func (x Extractor) next(s string, i int) (int, int) {
+	for ; i < len(s); i++ {
+		if isDigit(s[i]) {
+			break
+		}
+		if !x.Unsigned && s[i] == '-' && i+1 < len(s) && isDigit(s[i+1]) && (i == 0 || !isDigit(s[i-1])) {
+			break
+		}
+	}
+	if i >= len(s) {
+		return -1, -1
+	}
+	end := i + 1
+	for end < len(s) && isDigit(s[end]) {
+		end++
+	}
+	return i, end
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_'
+}
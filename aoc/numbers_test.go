@@ -0,0 +1,102 @@
+package aoc
+
+import (
+	"errors"
+	"math/big"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestInts(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		signed   []int
+		unsigned []int
+	}{
+		{"empty", "", []int{}, []int{}},
+		{"fields", "3   4", []int{3, 4}, []int{3, 4}},
+		{"robot", "p=0,4 v=3,-3", []int{0, 4, 3, -3}, []int{0, 4, 3, 3}},
+		{"leading minus", "-12 x", []int{-12}, []int{12}},
+		{"bare minus", "a - b -", []int{}, []int{}},
+		{"range", "10-20", []int{10, 20}, []int{10, 20}},
+		{"double minus", "--5", []int{-5}, []int{5}},
+		{"button", "Button A: X+94, Y+34", []int{94, 34}, []int{94, 34}},
+		{"program", "Program: 0,1,5,4,3,0", []int{0, 1, 5, 4, 3, 0}, []int{0, 1, 5, 4, 3, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Ints(tt.s); !reflect.DeepEqual(got, tt.signed) {
+				t.Errorf("Ints() = %v, want %v", got, tt.signed)
+			}
+			if got := UnsignedInts(tt.s); !reflect.DeepEqual(got, tt.unsigned) {
+				t.Errorf("UnsignedInts() = %v, want %v", got, tt.unsigned)
+			}
+		})
+	}
+}
+
+func TestExtractorStrict(t *testing.T) {
+	strict := Extractor{Strict: true}
+	tests := []struct {
+		name    string
+		s       string
+		col     int
+		text    string
+		wantErr error
+	}{
+		{"overflow", "1 99999999999999999999", 3, "99999999999999999999", strconv.ErrRange},
+		{"letters", "7 12ab", 3, "12ab", ErrMalformed},
+		{"decimal", "x=1.5", 3, "1.5", ErrMalformed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := strict.Int64s(tt.s)
+			var ne *NumberError
+			if !errors.As(err, &ne) {
+				t.Fatalf("Int64s() error = %v, want a NumberError", err)
+			}
+			if ne.Col != tt.col || ne.Text != tt.text || !errors.Is(err, tt.wantErr) {
+				t.Errorf("Int64s() error = %#v, want col %d text %q err %v", ne, tt.col, tt.text, tt.wantErr)
+			}
+		})
+	}
+
+	if got, err := strict.Ints("1.,2 end."); err != nil || !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Errorf("Ints() = %v, %v; trailing punctuation should be accepted", got, err)
+	}
+	if got, _ := (Extractor{}).Ints("12ab"); !reflect.DeepEqual(got, []int{12}) {
+		t.Errorf("non-strict Ints() = %v, want [12]", got)
+	}
+}
+
+func TestExtractorIntsInLines(t *testing.T) {
+	got, err := Extractor{Strict: true}.IntsInLines([]string{"1,2", "", "-3"})
+	if err != nil {
+		t.Fatalf("IntsInLines() error = %v", err)
+	}
+	if want := [][]int{{1, 2}, {}, {-3}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("IntsInLines() = %v, want %v", got, want)
+	}
+
+	_, err = Extractor{Strict: true}.IntsInLines([]string{"1", "2", "a 3x"})
+	var ne *NumberError
+	if !errors.As(err, &ne) || ne.Line != 3 || ne.Col != 3 {
+		t.Errorf("IntsInLines() error = %v, want line 3 column 3", err)
+	}
+	if err.Error() != `line 3, column 3: "3x": malformed number` {
+		t.Errorf("unexpected message %q", err.Error())
+	}
+}
+
+func TestExtractorBigInts(t *testing.T) {
+	got, err := Extractor{Strict: true}.BigInts("-123456789012345678901234567890, 7")
+	if err != nil {
+		t.Fatalf("BigInts() error = %v", err)
+	}
+	want, _ := new(big.Int).SetString("-123456789012345678901234567890", 10)
+	if len(got) != 2 || got[0].Cmp(want) != 0 || got[1].Int64() != 7 {
+		t.Errorf("BigInts() = %v", got)
+	}
+}
@@ -0,0 +1,21 @@
+package aoc
+
+import "os"
+
+// Quiet throws away anything written to stdout until the returned function
+// is called. Most of the days print their working as they go, which is handy
+// when running one by hand but swamps a table of results or a benchmark.
+//
+//	defer aoc.Quiet()()
+func Quiet() (restore func()) {
+	devnull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		return func() {}
+	}
+	stdout := os.Stdout
+	os.Stdout = devnull
+	return func() {
+		os.Stdout = stdout
+		devnull.Close()
+	}
+}
@@ -0,0 +1,147 @@
+package aoc
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Input is a puzzle input as it is handed to a Solver.
+type Input struct {
+	Name  string   // the input's name, like "sample" or "input"
+	Text  string   // the whole input, as returned by Text
+	Lines []string // the input split into lines, as returned by Lines
+	Args  []string // extra arguments from the command line, if any
+
+	// Done is closed when whoever is running the part stops waiting for
+	// it, as the aoc command does after --timeout. It's nil if they never
+	// will.
+	Done <-chan struct{}
+}
+
+// ErrStopped is what a part returns when it gives up because Stopped says
+// nobody is waiting for it any more.
+var ErrStopped = errors.New("stopped before finishing")
+
+// Stopped reports whether Done has been closed, so a long search can check
+// it now and then and give up once nobody wants the answer.
+func (in *Input) Stopped() bool {
+	select {
+	case <-in.Done:
+		return true
+	default:
+		return false
+	}
+}
+
+// NewInput reads r into an Input.
+func NewInput(name string, r io.Reader, args ...string) (*Input, error) {
+	text, err := Text(r)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	lines := []string{}
+	if text != "" {
+		lines = strings.Split(text, "\n")
+	}
+	return &Input{Name: name, Text: text, Lines: lines, Args: args}, nil
+}
+
+// ReadInput reads the named input from the current directory's data folder.
+func ReadInput(name string, args ...string) (*Input, error) {
+	r, err := Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return NewInput(name, r, args...)
+}
+
+// A Solver solves both parts of one day's puzzle. Answers are usually ints
+// but can be anything that prints sensibly.
+type Solver interface {
+	Part1(in *Input) (any, error)
+	Part2(in *Input) (any, error)
+}
+
+// A Part solves one part of a puzzle.
+type Part func(in *Input) (any, error)
+
+// Parts is a Solver made from a pair of Part functions.
+type Parts [2]Part
+
+func (p Parts) Part1(in *Input) (any, error) {
+	return p[0](in)
+}
+
+func (p Parts) Part2(in *Input) (any, error) {
+	return p[1](in)
+}
+
+// OnLines adapts the usual func(lines []string) signature to a Part.
+func OnLines[T any](f func([]string) T) Part {
+	return func(in *Input) (any, error) {
+		return f(in.Lines), nil
+	}
+}
+
+// OnText adapts a part that works on the whole input as one string.
+func OnText[T any](f func(string) T) Part {
+	return func(in *Input) (any, error) {
+		return f(in.Text), nil
+	}
+}
+
+// Day is a registered solution.
+type Day struct {
+	Number int
+	Solver Solver
+}
+
+var (
+	registryMu sync.Mutex
+	registry   = make(map[int]Solver)
+)
+
+// Register makes a day's solution available to the runner. It is meant to
+// be called from the day package's init function, and panics if the day is
+// registered twice.
+func Register(day int, s Solver) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, ok := registry[day]; ok {
+		panic(fmt.Sprintf("aoc: day %d registered twice", day))
+	}
+	registry[day] = s
+}
+
+// Lookup returns the solution registered for a day.
+func Lookup(day int) (Solver, bool) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	s, ok := registry[day]
+	return s, ok
+}
+
+// Days returns every registered day in order.
+func Days() []Day {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	days := make([]Day, 0, len(registry))
+	for n, s := range registry {
+		days = append(days, Day{Number: n, Solver: s})
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Number < days[j].Number })
+	return days
+}
+
+// OnLinesErr adapts a part that works on lines and can fail, such as one
+// that has to parse a grid first.
+func OnLinesErr[T any](f func([]string) (T, error)) Part {
+	return func(in *Input) (any, error) {
+		return f(in.Lines)
+	}
+}
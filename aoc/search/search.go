@@ -0,0 +1,238 @@
+// Package search finds shortest paths through graphs that are described by
+// a function from a state to its neighbors, so a maze, a grid with facing
+// directions or anything else with a comparable state can be searched
+// without building the graph first.
+package search
+
+import (
+	"container/heap"
+	"slices"
+)
+
+// Edge is a step to a neighboring state and what it costs to take it.
+type Edge[S comparable] struct {
+	To   S
+	Cost int
+}
+
+// Result holds the distances from the start to every state a search reached,
+// along with every state's predecessors on shortest paths. Since all the
+// predecessors are kept, not just one, it's a DAG of every optimal path.
+type Result[S comparable] struct {
+	Dist map[S]int
+	Prev map[S][]S
+}
+
+func newResult[S comparable]() *Result[S] {
+	return &Result[S]{Dist: make(map[S]int), Prev: make(map[S][]S)}
+}
+
+// Reached reports whether s was reached, and how far it is from the start.
+func (r *Result[S]) Reached(s S) (int, bool) {
+	d, ok := r.Dist[s]
+	return d, ok
+}
+
+// Path returns one shortest path from a start to goal, including both ends,
+// or nil if goal wasn't reached.
+func (r *Result[S]) Path(goal S) []S {
+	if _, ok := r.Dist[goal]; !ok {
+		return nil
+	}
+	path := []S{goal}
+	for s := goal; len(r.Prev[s]) > 0; {
+		s = r.Prev[s][0]
+		path = append(path, s)
+	}
+	slices.Reverse(path)
+	return path
+}
+
+// OnShortestPaths returns every state that's on at least one shortest path
+// to any of the goals. Goals that weren't reached are ignored; if there are
+// several, only the ones at the smallest distance count.
+func (r *Result[S]) OnShortestPaths(goals ...S) map[S]bool {
+	best, found := 0, false
+	for _, g := range goals {
+		if d, ok := r.Dist[g]; ok && (!found || d < best) {
+			best, found = d, true
+		}
+	}
+	on := make(map[S]bool)
+	var queue []S
+	for _, g := range goals {
+		if d, ok := r.Dist[g]; ok && d == best && !on[g] {
+			on[g] = true
+			queue = append(queue, g)
+		}
+	}
+	for len(queue) > 0 {
+		s := queue[0]
+		queue = queue[1:]
+		for _, p := range r.Prev[s] {
+			if !on[p] {
+				on[p] = true
+				queue = append(queue, p)
+			}
+		}
+	}
+	return on
+}
+
+// CountPaths returns the number of distinct shortest paths to goal.
+func (r *Result[S]) CountPaths(goal S) int {
+	if _, ok := r.Dist[goal]; !ok {
+		return 0
+	}
+	counts := make(map[S]int)
+	var count func(s S) int
+	count = func(s S) int {
+		if n, ok := counts[s]; ok {
+			return n
+		}
+		n := 0
+		if len(r.Prev[s]) == 0 {
+			n = 1 // a start
+		}
+		for _, p := range r.Prev[s] {
+			n += count(p)
+		}
+		counts[s] = n
+		return n
+	}
+	return count(goal)
+}
+
+// BFS finds the distance from the nearest start to every reachable state,
+// where each step costs 1.
+func BFS[S comparable](next func(S) []S, starts ...S) *Result[S] {
+	r := newResult[S]()
+	var queue []S
+	for _, s := range starts {
+		if _, ok := r.Dist[s]; !ok {
+			r.Dist[s] = 0
+			queue = append(queue, s)
+		}
+	}
+	for len(queue) > 0 {
+		s := queue[0]
+		queue = queue[1:]
+		d := r.Dist[s] + 1
+		for _, n := range next(s) {
+			nd, seen := r.Dist[n]
+			switch {
+			case !seen:
+				r.Dist[n] = d
+				r.Prev[n] = []S{s}
+				queue = append(queue, n)
+			case nd == d:
+				r.Prev[n] = append(r.Prev[n], s)
+			}
+		}
+	}
+	return r
+}
+
+// Dijkstra finds the cheapest cost from the nearest start to every reachable
+// state. Costs must not be negative.
+func Dijkstra[S comparable](next func(S) []Edge[S], starts ...S) *Result[S] {
+	r := newResult[S]()
+	search(r, next, func(S) int { return 0 }, nil, starts)
+	return r
+}
+
+// AStar finds the cheapest path from start to a state for which isGoal is
+// true, visiting as little as it can on the way. The heuristic h must never
+// overestimate the remaining cost (Manhattan distance is the usual one on a
+// grid). It returns the path including both ends and its cost, or false if
+// no goal can be reached.
+func AStar[S comparable](start S, isGoal func(S) bool, next func(S) []Edge[S], h func(S) int) ([]S, int, bool) {
+	r := newResult[S]()
+	goal, ok := search(r, next, h, isGoal, []S{start})
+	if !ok {
+		return nil, 0, false
+	}
+	return r.Path(goal), r.Dist[goal], true
+}
+
+// search is the guts of Dijkstra and A*. If isGoal is set it stops at the
+// first goal it settles and returns it; otherwise it settles everything.
+func search[S comparable](r *Result[S], next func(S) []Edge[S], h func(S) int, isGoal func(S) bool, starts []S) (S, bool) {
+	pq := &queue[S]{}
+	for _, s := range starts {
+		if _, ok := r.Dist[s]; !ok {
+			r.Dist[s] = 0
+			heap.Push(pq, item[S]{s, 0, h(s)})
+		}
+	}
+	done := make(map[S]bool)
+	for pq.Len() > 0 {
+		it := heap.Pop(pq).(item[S])
+		if done[it.s] || it.cost > r.Dist[it.s] {
+			continue
+		}
+		done[it.s] = true
+		if isGoal != nil && isGoal(it.s) {
+			return it.s, true
+		}
+		for _, e := range next(it.s) {
+			cost := it.cost + e.Cost
+			old, seen := r.Dist[e.To]
+			switch {
+			case !seen || cost < old:
+				r.Dist[e.To] = cost
+				r.Prev[e.To] = []S{it.s}
+				heap.Push(pq, item[S]{e.To, cost, cost + h(e.To)})
+			case cost == old && !done[e.To]:
+				r.Prev[e.To] = append(r.Prev[e.To], it.s)
+			}
+		}
+	}
+	var zero S
+	return zero, false
+}
+
+type item[S comparable] struct {
+	s        S
+	cost     int
+	priority int
+}
+
+// queue is a min-heap of items by priority.
+type queue[S comparable] []item[S]
+
+func (q queue[S]) Len() int           { return len(q) }
+func (q queue[S]) Less(i, j int) bool { return q[i].priority < q[j].priority }
+func (q queue[S]) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *queue[S]) Push(x any)        { *q = append(*q, x.(item[S])) }
+func (q *queue[S]) Pop() any {
+	old := *q
+	it := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return it
+}
+
+// CountPaths counts the distinct paths from start to any state for which
+// isEnd is true, following next. The graph has to be acyclic, like a trail
+// that only goes uphill; counts for each state are remembered, so shared
+// parts of paths are only walked once. Paths stop at the first end they
+// reach.
+func CountPaths[S comparable](start S, next func(S) []S, isEnd func(S) bool) int {
+	counts := make(map[S]int)
+	var count func(s S) int
+	count = func(s S) int {
+		if isEnd(s) {
+			return 1
+		}
+		if n, ok := counts[s]; ok {
+			return n
+		}
+		n := 0
+		for _, t := range next(s) {
+			n += count(t)
+		}
+		counts[s] = n
+		return n
+	}
+	return count(start)
+}
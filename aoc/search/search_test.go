@@ -0,0 +1,208 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kentquirk/aoc2024/aoc/geom"
+	"github.com/kentquirk/aoc2024/aoc/grid"
+)
+
+func maze(t *testing.T, lines ...string) (*grid.Grid[byte], func(geom.Point) []geom.Point) {
+	t.Helper()
+	g, err := grid.Bytes(lines)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g, func(p geom.Point) []geom.Point {
+		var ns []geom.Point
+		for n, c := range g.Neighbors4(p) {
+			if c != '#' {
+				ns = append(ns, n)
+			}
+		}
+		return ns
+	}
+}
+
+func unitCost(next func(geom.Point) []geom.Point) func(geom.Point) []Edge[geom.Point] {
+	return func(p geom.Point) []Edge[geom.Point] {
+		var es []Edge[geom.Point]
+		for _, n := range next(p) {
+			es = append(es, Edge[geom.Point]{n, 1})
+		}
+		return es
+	}
+}
+
+func pt(x, y int) geom.Point {
+	return geom.Point{X: x, Y: y}
+}
+
+func TestBFS(t *testing.T) {
+	_, next := maze(t,
+		"..#.",
+		".##.",
+		"....",
+	)
+	r := BFS(next, pt(0, 0))
+	tests := []struct {
+		p      geom.Point
+		want   int
+		wantOK bool
+	}{
+		{pt(0, 0), 0, true},
+		{pt(1, 0), 1, true},
+		{pt(0, 2), 2, true},
+		{pt(3, 0), 7, true},
+		{pt(2, 0), 0, false}, // a wall
+	}
+	for _, tt := range tests {
+		t.Run(tt.p.String(), func(t *testing.T) {
+			got, ok := r.Reached(tt.p)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("Reached(%v) = %d, %v, want %d, %v", tt.p, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+	want := []geom.Point{pt(0, 0), pt(0, 1), pt(0, 2), pt(1, 2), pt(2, 2), pt(3, 2), pt(3, 1), pt(3, 0)}
+	if got := r.Path(pt(3, 0)); !reflect.DeepEqual(got, want) {
+		t.Errorf("Path() = %v, want %v", got, want)
+	}
+	if got := r.Path(pt(2, 0)); got != nil {
+		t.Errorf("Path() to a wall = %v", got)
+	}
+}
+
+func TestBFSMultipleStarts(t *testing.T) {
+	_, next := maze(t, ".....")
+	r := BFS(next, pt(0, 0), pt(4, 0))
+	if got := r.Dist[pt(2, 0)]; got != 2 {
+		t.Errorf("middle is %d away, want 2", got)
+	}
+	if got := r.Dist[pt(3, 0)]; got != 1 {
+		t.Errorf("(3,0) is %d away, want 1", got)
+	}
+}
+
+func TestAllShortestPaths(t *testing.T) {
+	_, next := maze(t,
+		"...",
+		"...",
+		"...",
+	)
+	for _, r := range []*Result[geom.Point]{BFS(next, pt(0, 0)), Dijkstra(unitCost(next), pt(0, 0))} {
+		// there are C(4,2) ways to get from one corner to the other
+		if got := r.CountPaths(pt(2, 2)); got != 6 {
+			t.Errorf("CountPaths() = %d, want 6", got)
+		}
+		if got := r.CountPaths(pt(2, 0)); got != 1 {
+			t.Errorf("CountPaths() along an edge = %d, want 1", got)
+		}
+		if got := len(r.OnShortestPaths(pt(2, 2))); got != 9 {
+			t.Errorf("OnShortestPaths() has %d cells, want all 9", got)
+		}
+		on := r.OnShortestPaths(pt(1, 0))
+		if want := map[geom.Point]bool{pt(0, 0): true, pt(1, 0): true}; !reflect.DeepEqual(on, want) {
+			t.Errorf("OnShortestPaths() = %v, want %v", on, want)
+		}
+	}
+}
+
+// reindeer is a state with a facing, where turning costs 1000, like day 16
+type reindeer struct {
+	p geom.Point
+	d geom.Dir4
+}
+
+func TestDijkstraWithTurns(t *testing.T) {
+	g, _ := maze(t,
+		"#######",
+		"#....E#",
+		"#.#.#.#",
+		"#S....#",
+		"#######",
+	)
+	next := func(r reindeer) []Edge[reindeer] {
+		es := []Edge[reindeer]{
+			{reindeer{r.p, r.d.TurnLeft()}, 1000},
+			{reindeer{r.p, r.d.TurnRight()}, 1000},
+		}
+		if g.At(r.p.Move(r.d)) != '#' {
+			es = append(es, Edge[reindeer]{reindeer{r.p.Move(r.d), r.d}, 1})
+		}
+		return es
+	}
+	start := reindeer{grid.Find(g, 'S')[0], geom.Right}
+	end := grid.Find(g, 'E')[0]
+	r := Dijkstra(next, start)
+
+	// one turn is the least it can do, and only going right first manages it
+	var goals []reindeer
+	for _, d := range geom.Dirs4 {
+		goals = append(goals, reindeer{end, d})
+	}
+	best := -1
+	for _, goal := range goals {
+		if d, ok := r.Reached(goal); ok && (best < 0 || d < best) {
+			best = d
+		}
+	}
+	if best != 1006 {
+		t.Errorf("best cost = %d, want 1006", best)
+	}
+	tiles := make(map[geom.Point]bool)
+	for s := range r.OnShortestPaths(goals...) {
+		tiles[s.p] = true
+	}
+	if len(tiles) != 7 {
+		t.Errorf("%d tiles on best paths, want 7", len(tiles))
+	}
+
+	path, cost, ok := AStar(start, func(r reindeer) bool { return r.p == end }, next,
+		func(r reindeer) int { return r.p.Manhattan(end) })
+	if !ok || cost != best {
+		t.Fatalf("AStar() = %d, %v, want %d", cost, ok, best)
+	}
+	if path[0] != start || path[len(path)-1].p != end {
+		t.Errorf("AStar() path runs from %v to %v", path[0], path[len(path)-1])
+	}
+}
+
+func TestAStarNoPath(t *testing.T) {
+	_, next := maze(t, "..#..")
+	_, _, ok := AStar(pt(0, 0), func(p geom.Point) bool { return p == pt(4, 0) }, unitCost(next),
+		func(p geom.Point) int { return p.Manhattan(pt(4, 0)) })
+	if ok {
+		t.Error("AStar() found a path through a wall")
+	}
+}
+
+func TestCountPaths(t *testing.T) {
+	// a little trail map: every path climbs one step at a time from 0 to 3
+	g, err := grid.Parse([]string{
+		"0123",
+		"1234",
+		"2345",
+	}, func(_ geom.Point, b byte) int { return int(b - '0') })
+	if err != nil {
+		t.Fatal(err)
+	}
+	next := func(p geom.Point) []geom.Point {
+		var ns []geom.Point
+		for n, h := range g.Neighbors4(p) {
+			if h == g.At(p)+1 {
+				ns = append(ns, n)
+			}
+		}
+		return ns
+	}
+	isEnd := func(p geom.Point) bool { return g.At(p) == 3 }
+	// the three 3s are 1, 3 and 3 ways from the corner
+	if got := CountPaths(pt(0, 0), next, isEnd); got != 7 {
+		t.Errorf("CountPaths() = %d, want 7", got)
+	}
+	if got := CountPaths(pt(3, 0), next, isEnd); got != 1 {
+		t.Errorf("CountPaths() from an end = %d, want 1", got)
+	}
+}
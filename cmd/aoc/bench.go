@@ -0,0 +1,142 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"time"
+
+	"github.com/kentquirk/aoc2024/aoc"
+)
+
+// benchResult is the timing for one part, averaged over the runs.
+type benchResult struct {
+	Day         int    `json:"day"`
+	Part        int    `json:"part"`
+	Input       string `json:"input"`
+	Runs        int    `json:"runs"`
+	NsPerOp     int64  `json:"ns_per_op"`
+	AllocsPerOp uint64 `json:"allocs_per_op"`
+	BytesPerOp  uint64 `json:"bytes_per_op"`
+	Err         string `json:"error,omitempty"`
+}
+
+// benchCmd times each part and writes the results as a Markdown or JSON
+// table, so a before and after of an optimization can be compared.
+func benchCmd(args []string) error {
+	fs := flag.NewFlagSet("bench", flag.ContinueOnError)
+	input := fs.String("input", "input", "name of the input file in each day's data directory")
+	root := fs.String("root", "", "repository root (default: the directory holding go.work)")
+	timeout := fs.Duration("timeout", 0, "stop waiting for a part after this long; the part isn't killed, only asked to stop (0 means wait forever)")
+	count := fs.Int("count", 1, "run each part this many times and report the average")
+	format := fs.String("format", "md", "output format: md or json")
+	out := fs.String("o", "", "write the report to this file instead of stdout")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 {
+		positional = []string{"all"}
+	}
+	if *count < 1 {
+		return errors.New("bench: --count must be at least 1")
+	}
+	write, ok := benchFormats[*format]
+	if !ok {
+		return fmt.Errorf("bench: unknown format %q (want md or json)", *format)
+	}
+	if *root == "" {
+		if *root, err = findRoot(); err != nil {
+			return err
+		}
+	}
+	days, err := parseDays(positional[0], registeredDays())
+	if err != nil {
+		return err
+	}
+
+	var results []benchResult
+	for _, day := range days {
+		s, _ := aoc.Lookup(day)
+		in, err := loadInput(*root, day, *input, positional[1:])
+		for part, f := range []func(*aoc.Input) (any, error){s.Part1, s.Part2} {
+			if err != nil {
+				results = append(results, benchResult{Day: day, Part: part + 1, Input: *input, Err: err.Error()})
+				continue
+			}
+			r := benchPart(day, part+1, f, in, *count, *timeout)
+			fmt.Fprintf(os.Stderr, "day %d part %d: %v\n", day, part+1, time.Duration(r.NsPerOp).Round(time.Microsecond))
+			results = append(results, r)
+		}
+	}
+
+	w := stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	return write(w, results)
+}
+
+// benchPart runs a part count times and averages the time and allocations.
+// It stops at the first failure, since a part that fails or times out once
+// will keep doing it.
+func benchPart(day, part int, f func(*aoc.Input) (any, error), in *aoc.Input, count int, timeout time.Duration) benchResult {
+	br := benchResult{Day: day, Part: part, Input: in.Name}
+	var before, after runtime.MemStats
+	var elapsed time.Duration
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	for br.Runs < count {
+		r := runPart(day, part, f, in, timeout, false)
+		if r.err != nil {
+			br.Err = r.err.Error()
+			break
+		}
+		elapsed += r.elapsed
+		br.Runs++
+	}
+	runtime.ReadMemStats(&after)
+	if br.Runs > 0 {
+		br.NsPerOp = elapsed.Nanoseconds() / int64(br.Runs)
+		br.AllocsPerOp = (after.Mallocs - before.Mallocs) / uint64(br.Runs)
+		br.BytesPerOp = (after.TotalAlloc - before.TotalAlloc) / uint64(br.Runs)
+	}
+	return br
+}
+
+var benchFormats = map[string]func(io.Writer, []benchResult) error{
+	"md":   writeMarkdown,
+	"json": writeJSON,
+}
+
+func writeMarkdown(w io.Writer, results []benchResult) error {
+	fmt.Fprintln(w, "| day | part | input | runs | time/op | allocs/op | bytes/op |")
+	fmt.Fprintln(w, "|----:|-----:|-------|-----:|--------:|----------:|---------:|")
+	for _, r := range results {
+		if r.Err != "" {
+			fmt.Fprintf(w, "| %d | %d | %s | %d | %s | | |\n", r.Day, r.Part, r.Input, r.Runs, "FAIL: "+r.Err)
+			continue
+		}
+		_, err := fmt.Fprintf(w, "| %d | %d | %s | %d | %v | %d | %d |\n", r.Day, r.Part, r.Input, r.Runs,
+			time.Duration(r.NsPerOp).Round(time.Microsecond), r.AllocsPerOp, r.BytesPerOp)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeJSON(w io.Writer, results []benchResult) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(results)
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kentquirk/aoc2024/aoc"
+)
+
+func Test_benchPart(t *testing.T) {
+	in := &aoc.Input{Name: "test"}
+	calls := 0
+	count := func(*aoc.Input) (any, error) { calls++; return make([]int, 100), nil }
+	r := benchPart(1, 2, count, in, 3, 0)
+	if r.Err != "" || r.Runs != 3 || calls != 3 {
+		t.Fatalf("benchPart() = %+v after %d calls", r, calls)
+	}
+	if r.AllocsPerOp < 1 || r.BytesPerOp < 800 {
+		t.Errorf("benchPart() didn't see the allocation: %+v", r)
+	}
+
+	fail := func(*aoc.Input) (any, error) { return nil, errors.New("nope") }
+	if r := benchPart(1, 2, fail, in, 3, time.Second); r.Err != "nope" || r.Runs != 0 {
+		t.Errorf("benchPart() = %+v, want a failure with no runs", r)
+	}
+}
+
+func Test_writeMarkdown(t *testing.T) {
+	results := []benchResult{
+		{Day: 6, Part: 1, Input: "input", Runs: 2, NsPerOp: 1500000, AllocsPerOp: 10, BytesPerOp: 2048},
+		{Day: 6, Part: 2, Input: "input", Err: "timed out after 1s"},
+	}
+	want := "| day | part | input | runs | time/op | allocs/op | bytes/op |\n" +
+		"|----:|-----:|-------|-----:|--------:|----------:|---------:|\n" +
+		"| 6 | 1 | input | 2 | 1.5ms | 10 | 2048 |\n" +
+		"| 6 | 2 | input | 0 | FAIL: timed out after 1s | | |\n"
+	var buf bytes.Buffer
+	if err := writeMarkdown(&buf, results); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != want {
+		t.Errorf("writeMarkdown() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/kentquirk/aoc2024/aoc"
+)
+
+// checkCmd runs days against their recorded answers and reports mismatches,
+// and the parts the answers files mark as skipped.
+func checkCmd(args []string) error {
+	fs := flag.NewFlagSet("check", flag.ContinueOnError)
+	input := fs.String("input", "", "only check this input (default: every input with an answers file)")
+	root := fs.String("root", "", "repository root (default: the directory holding go.work)")
+	timeout := fs.Duration("timeout", 0, "stop waiting for a part after this long; the part isn't killed, only asked to stop (0 means wait forever)")
+	verbose := fs.Bool("v", false, "show the solutions' own output")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 {
+		positional = []string{"all"}
+	}
+	if *root == "" {
+		if *root, err = findRoot(); err != nil {
+			return err
+		}
+	}
+	days, err := parseDays(positional[0], registeredDays())
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "%-4s %-12s %-4s %-8s %12s\n", "day", "input", "part", "result", "time")
+	checked, failed, skipped := 0, 0, 0
+	for _, day := range days {
+		names := []string{*input}
+		if *input == "" {
+			if names, err = aoc.AnswerInputs(dayDir(*root, day)); err != nil {
+				return err
+			}
+		}
+		for _, name := range names {
+			answers, err := aoc.ReadAnswers(filepath.Join(dayDir(*root, day), aoc.AnswersFile(name)))
+			if err != nil {
+				return err
+			}
+			var parts []int
+			for part := 1; part <= 2; part++ {
+				if _, ok := answers.Want(part); ok {
+					parts = append(parts, part)
+				}
+			}
+			results := runDay(*root, day, name, answers.Args, parts, *timeout, *verbose)
+			// report the parts in order, with the skipped ones among them
+			for part := 1; part <= 2; part++ {
+				if reason, ok := answers.Skipped(part); ok {
+					skipped++
+					fmt.Fprintf(stdout, "%-4d %-12s %-4d %s\n", day, name, part, "skipped")
+					fmt.Fprintf(stdout, "\t%s\n", reason)
+					continue
+				}
+				if len(results) == 0 || results[0].part != part {
+					continue
+				}
+				r := results[0]
+				results = results[1:]
+				checked++
+				want, _ := answers.Want(r.part)
+				status, detail := checkResult(r, want)
+				if status != "ok" {
+					failed++
+				}
+				fmt.Fprintf(stdout, "%-4d %-12s %-4d %-8s %12v\n", day, name, r.part, status, r.elapsed.Round(time.Microsecond))
+				if detail != "" {
+					fmt.Fprintln(stdout, detail)
+				}
+			}
+		}
+	}
+	fmt.Fprintf(stdout, "%d checked, %d failed, %d skipped\n", checked, failed, skipped)
+	if checked == 0 {
+		return errors.New("no answers to check")
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d answer(s) wrong", failed)
+	}
+	return nil
+}
+
+// checkResult compares a result with the expected answer and returns a
+// status word and, for failures, an indented explanation.
+func checkResult(r result, want string) (string, string) {
+	if r.err != nil {
+		return "FAIL", fmt.Sprintf("\t%v", r.err)
+	}
+	got := aoc.FormatAnswer(r.answer)
+	if got != want {
+		return "WRONG", fmt.Sprintf("\tgot:  %s\n\twant: %s", got, want)
+	}
+	return "ok", ""
+}
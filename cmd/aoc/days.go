@@ -0,0 +1,27 @@
+package main
+
+// Each day registers itself with the aoc package when it's imported.
+import (
+	_ "github.com/kentquirk/aoc2024/day01"
+	_ "github.com/kentquirk/aoc2024/day02"
+	_ "github.com/kentquirk/aoc2024/day03"
+	_ "github.com/kentquirk/aoc2024/day04"
+	_ "github.com/kentquirk/aoc2024/day05"
+	_ "github.com/kentquirk/aoc2024/day06"
+	_ "github.com/kentquirk/aoc2024/day07"
+	_ "github.com/kentquirk/aoc2024/day08"
+	_ "github.com/kentquirk/aoc2024/day09"
+	_ "github.com/kentquirk/aoc2024/day10"
+	_ "github.com/kentquirk/aoc2024/day12"
+	_ "github.com/kentquirk/aoc2024/day13"
+	_ "github.com/kentquirk/aoc2024/day14"
+	_ "github.com/kentquirk/aoc2024/day16"
+	_ "github.com/kentquirk/aoc2024/day17"
+	_ "github.com/kentquirk/aoc2024/day18"
+	_ "github.com/kentquirk/aoc2024/day19"
+	_ "github.com/kentquirk/aoc2024/day20"
+	_ "github.com/kentquirk/aoc2024/day22"
+	_ "github.com/kentquirk/aoc2024/day23"
+	_ "github.com/kentquirk/aoc2024/day24"
+	_ "github.com/kentquirk/aoc2024/day25"
+)
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kentquirk/aoc2024/aoc"
+	"github.com/kentquirk/aoc2024/aoc/client"
+)
+
+// clientFlags adds the flags for talking to the site to flags, and returns a
+// function that makes a client from them once they've been parsed.
+func clientFlags(flags *flag.FlagSet) func() (*client.Client, error) {
+	baseURL := flags.String("url", client.DefaultURL, "site to talk to (default: $AOC_URL if it's set)")
+	session := flags.String("session", "", "session cookie (default: $AOC_SESSION, or the contents of ~/.config/aoc/session)")
+	year := flags.Int("year", 2024, "puzzle year")
+	return func() (*client.Client, error) {
+		u := *baseURL
+		if env := os.Getenv("AOC_URL"); env != "" && !flagSet(flags, "url") {
+			u = env
+		}
+		s, err := findSession(*session)
+		if err != nil {
+			return nil, err
+		}
+		return client.New(u, s, *year), nil
+	}
+}
+
+func flagSet(flags *flag.FlagSet, name string) bool {
+	set := false
+	flags.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+// findSession looks for the session cookie in the flag, then the
+// environment, then a file in the user's config directory.
+func findSession(flagValue string) (string, error) {
+	if flagValue != "" {
+		return flagValue, nil
+	}
+	if s := os.Getenv("AOC_SESSION"); s != "" {
+		return s, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err == nil {
+		b, err := os.ReadFile(filepath.Join(dir, "aoc", "session"))
+		if err == nil {
+			return strings.TrimSpace(string(b)), nil
+		}
+	}
+	return "", errors.New("no session: use --session, set AOC_SESSION, or put it in ~/.config/aoc/session")
+}
+
+// fetchCmd downloads puzzle inputs into each day's data directory. An input
+// that's already there is left alone unless --force is given.
+func fetchCmd(args []string) error {
+	flags := flag.NewFlagSet("fetch", flag.ContinueOnError)
+	root := flags.String("root", "", "repository root (default: the directory holding go.work)")
+	force := flags.Bool("force", false, "fetch again even if the input is already saved")
+	newClient := clientFlags(flags)
+	positional, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 {
+		return errors.New("fetch: which days? (a number, a range like 1-5, or all)")
+	}
+	if *root == "" {
+		if *root, err = findRoot(); err != nil {
+			return err
+		}
+	}
+	// inputs are wanted before there's a solution to run on them
+	days, err := parseDays(positional[0], puzzleDays())
+	if err != nil {
+		return err
+	}
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
+	for _, day := range days {
+		fetched, err := fetchDay(c, *root, day, *force)
+		if err != nil {
+			return fmt.Errorf("day %d: %w", day, err)
+		}
+		if fetched {
+			fmt.Fprintf(stdout, "day %d: fetched\n", day)
+		} else {
+			fmt.Fprintf(stdout, "day %d: already saved\n", day)
+		}
+	}
+	return nil
+}
+
+// fetchDay saves a day's input as data/input.txt, unless it's already there
+// and force isn't set, making the data directory if need be. It reports
+// whether it went to the site.
+func fetchDay(c *client.Client, root string, day int, force bool) (bool, error) {
+	path := filepath.Join(dayDir(root, day), aoc.DataFile("input"))
+	if _, err := os.Stat(path); err == nil && !force {
+		return false, nil
+	}
+	input, err := c.Input(day)
+	if err != nil {
+		return false, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return false, err
+	}
+	return true, os.WriteFile(path, []byte(input), 0o644)
+}
+
+// submitCmd sends an answer to the site and records what it says in the
+// input's answers file. With no answer on the command line, it runs the day
+// to get one.
+func submitCmd(args []string) error {
+	flags := flag.NewFlagSet("submit", flag.ContinueOnError)
+	root := flags.String("root", "", "repository root (default: the directory holding go.work)")
+	input := flags.String("input", "input", "input to run the day on and record the answer for")
+	timeout := flags.Duration("timeout", 0, "stop waiting for the part after this long; the part isn't killed, only asked to stop (0 means wait forever)")
+	newClient := clientFlags(flags)
+	positional, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) < 2 || len(positional) > 3 {
+		return errors.New("submit: need a day, a part, and optionally the answer")
+	}
+	day, err := strconv.Atoi(positional[0])
+	if err != nil {
+		return fmt.Errorf("bad day %q", positional[0])
+	}
+	part, err := strconv.Atoi(positional[1])
+	if err != nil || part < 1 || part > 2 {
+		return fmt.Errorf("bad part %q", positional[1])
+	}
+	if *root == "" {
+		if *root, err = findRoot(); err != nil {
+			return err
+		}
+	}
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(dayDir(*root, day), aoc.AnswersFile(*input))
+	answers, err := aoc.ReadAnswers(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	var answer string
+	if len(positional) == 3 {
+		answer = positional[2]
+	} else {
+		if _, ok := aoc.Lookup(day); !ok {
+			return fmt.Errorf("day %d has no registered solution", day)
+		}
+		r := runDay(*root, day, *input, answers.Args, []int{part}, *timeout, false)[0]
+		if r.err != nil {
+			return r.err
+		}
+		answer = aoc.FormatAnswer(r.answer)
+	}
+	return submitAnswer(c, path, answers, day, part, answer)
+}
+
+// submitAnswer sends an answer unless the answers file already says whether
+// it's right, and records the verdict there.
+func submitAnswer(c *client.Client, path string, answers aoc.Answers, day, part int, answer string) error {
+	if reason, ok := answers.Skipped(part); ok {
+		return fmt.Errorf("day %d part %d is marked as skipped: %s", day, part, reason)
+	}
+	if known, ok := answers.Want(part); ok {
+		if known == answer {
+			fmt.Fprintf(stdout, "day %d part %d: %s is already recorded as right\n", day, part, answer)
+			return nil
+		}
+		return fmt.Errorf("day %d part %d: the recorded answer is %s, not sending %s", day, part, known, answer)
+	}
+	if answers.IsWrong(part, answer) {
+		return fmt.Errorf("day %d part %d: %s has already been turned down", day, part, answer)
+	}
+
+	v, err := c.Submit(day, part, answer)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "day %d part %d: %s is %v\n", day, part, answer, v.Outcome)
+	switch v.Outcome {
+	case client.Correct:
+		return aoc.RecordAnswer(path, part, answer)
+	case client.Wrong:
+		if err := aoc.RecordWrongAnswer(path, part, answer); err != nil {
+			return err
+		}
+		if v.Hint != "" {
+			return fmt.Errorf("wrong answer (%s)", v.Hint)
+		}
+		return errors.New("wrong answer")
+	case client.TooSoon:
+		return fmt.Errorf("answered too recently; try again in %v", v.Wait.Round(time.Second))
+	case client.AlreadySolved:
+		return nil
+	}
+	return fmt.Errorf("couldn't make sense of the response: %s", v.Message)
+}
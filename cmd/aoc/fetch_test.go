@@ -0,0 +1,107 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kentquirk/aoc2024/aoc"
+	"github.com/kentquirk/aoc2024/aoc/client"
+	"github.com/kentquirk/aoc2024/aoc/client/aocfake"
+)
+
+func fakeSite(t *testing.T) (*client.Client, *aocfake.Server) {
+	t.Helper()
+	s := aocfake.New(2024, "secret")
+	t.Cleanup(s.Close)
+	s.SetInput(1, "3   4\n4   3\n")
+	s.SetAnswer(1, 1, "11")
+	s.Lockout = 0
+	c := client.New(s.URL, "secret", 2024)
+	c.Interval = 0
+	return c, s
+}
+
+func Test_fetchDay(t *testing.T) {
+	c, s := fakeSite(t)
+	root := t.TempDir()
+	// no data directory yet, as for a day that hasn't been started
+	path := filepath.Join(dayDir(root, 1), aoc.DataFile("input"))
+
+	steps := []struct {
+		name        string
+		force       bool
+		wantFetched bool
+		wantReqs    int
+	}{
+		{"first time", false, true, 1},
+		{"saved", false, false, 1},
+		{"forced", true, true, 2},
+	}
+	for _, step := range steps {
+		fetched, err := fetchDay(c, root, 1, step.force)
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		if fetched != step.wantFetched || s.Requests() != step.wantReqs {
+			t.Errorf("%s: fetched = %v after %d requests, want %v after %d", step.name, fetched, s.Requests(), step.wantFetched, step.wantReqs)
+		}
+		if b, _ := os.ReadFile(path); string(b) != "3   4\n4   3\n" {
+			t.Errorf("%s: saved input is %q", step.name, b)
+		}
+	}
+
+	if _, err := fetchDay(c, root, 2, false); err == nil {
+		t.Error("fetchDay() should fail for a day that doesn't exist")
+	}
+	if _, err := os.Stat(dayDir(root, 2)); err == nil {
+		t.Error("fetchDay() made a directory for a day it couldn't fetch")
+	}
+}
+
+func Test_submitAnswer(t *testing.T) {
+	c, s := fakeSite(t)
+	path := filepath.Join(t.TempDir(), aoc.AnswersFile("input"))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	steps := []struct {
+		name      string
+		answer    string
+		wantErr   bool
+		wantSends int
+	}{
+		{"wrong", "12", true, 1},
+		{"same wrong again", "12", true, 1}, // not sent
+		{"right", "11", false, 2},
+		{"right again", "11", false, 2}, // not sent
+		{"different", "10", true, 2},    // not sent
+	}
+	for _, step := range steps {
+		answers, err := aoc.ReadAnswers(path)
+		if err != nil && !os.IsNotExist(err) {
+			t.Fatal(err)
+		}
+		err = submitAnswer(c, path, answers, 1, 1, step.answer)
+		if (err != nil) != step.wantErr {
+			t.Errorf("%s: submitAnswer() error = %v, wantErr %v", step.name, err, step.wantErr)
+		}
+		if got := len(s.Submissions()); got != step.wantSends {
+			t.Errorf("%s: %d answers sent, want %d", step.name, got, step.wantSends)
+		}
+	}
+
+	answers, err := aoc.ReadAnswers(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if answers.Part1 != "11" || !answers.IsWrong(1, "12") {
+		t.Errorf("recorded answers are %+v", answers)
+	}
+
+	answers.Skip[1] = "no part 2"
+	if err := submitAnswer(c, path, answers, 1, 2, "0"); err == nil || len(s.Submissions()) != 2 {
+		t.Errorf("submitAnswer() of a skipped part = %v after %d sends, want an error and nothing sent", err, len(s.Submissions()))
+	}
+}
@@ -0,0 +1,55 @@
+module github.com/kentquirk/aoc2024/cmd/aoc
+
+go 1.23
+
+require (
+	github.com/kentquirk/aoc2024 v0.0.0
+	github.com/kentquirk/aoc2024/day01 v0.0.0
+	github.com/kentquirk/aoc2024/day02 v0.0.0
+	github.com/kentquirk/aoc2024/day03 v0.0.0
+	github.com/kentquirk/aoc2024/day04 v0.0.0
+	github.com/kentquirk/aoc2024/day05 v0.0.0
+	github.com/kentquirk/aoc2024/day06 v0.0.0
+	github.com/kentquirk/aoc2024/day07 v0.0.0
+	github.com/kentquirk/aoc2024/day08 v0.0.0
+	github.com/kentquirk/aoc2024/day09 v0.0.0
+	github.com/kentquirk/aoc2024/day10 v0.0.0
+	github.com/kentquirk/aoc2024/day12 v0.0.0
+	github.com/kentquirk/aoc2024/day13 v0.0.0
+	github.com/kentquirk/aoc2024/day14 v0.0.0
+	github.com/kentquirk/aoc2024/day16 v0.0.0
+	github.com/kentquirk/aoc2024/day17 v0.0.0
+	github.com/kentquirk/aoc2024/day18 v0.0.0
+	github.com/kentquirk/aoc2024/day19 v0.0.0
+	github.com/kentquirk/aoc2024/day20 v0.0.0
+	github.com/kentquirk/aoc2024/day22 v0.0.0
+	github.com/kentquirk/aoc2024/day23 v0.0.0
+	github.com/kentquirk/aoc2024/day24 v0.0.0
+	github.com/kentquirk/aoc2024/day25 v0.0.0
+)
+
+replace (
+	github.com/kentquirk/aoc2024 => ../../
+	github.com/kentquirk/aoc2024/day01 => ../../day01_go
+	github.com/kentquirk/aoc2024/day02 => ../../day02_go
+	github.com/kentquirk/aoc2024/day03 => ../../day03_go
+	github.com/kentquirk/aoc2024/day04 => ../../day04_go
+	github.com/kentquirk/aoc2024/day05 => ../../day05_go
+	github.com/kentquirk/aoc2024/day06 => ../../day06_go
+	github.com/kentquirk/aoc2024/day07 => ../../day07_go
+	github.com/kentquirk/aoc2024/day08 => ../../day08_go
+	github.com/kentquirk/aoc2024/day09 => ../../day09_go
+	github.com/kentquirk/aoc2024/day10 => ../../day10_go
+	github.com/kentquirk/aoc2024/day12 => ../../day12_go
+	github.com/kentquirk/aoc2024/day13 => ../../day13_go
+	github.com/kentquirk/aoc2024/day14 => ../../day14_go
+	github.com/kentquirk/aoc2024/day16 => ../../day16_go
+	github.com/kentquirk/aoc2024/day17 => ../../day17_go
+	github.com/kentquirk/aoc2024/day18 => ../../day18_go
+	github.com/kentquirk/aoc2024/day19 => ../../day19_go
+	github.com/kentquirk/aoc2024/day20 => ../../day20_go
+	github.com/kentquirk/aoc2024/day22 => ../../day22_go
+	github.com/kentquirk/aoc2024/day23 => ../../day23_go
+	github.com/kentquirk/aoc2024/day24 => ../../day24_go
+	github.com/kentquirk/aoc2024/day25 => ../../day25_go
+)
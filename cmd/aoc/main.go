@@ -0,0 +1,113 @@
+// Command aoc runs the Advent of Code solutions in this repository.
+//
+// Usage:
+//
+//	aoc run <days> [--input name] [args...]
+//	aoc check <days> [--input name]
+//	aoc bench <days> [--input name] [--count n] [--format md|json] [-o file]
+//	aoc new <day> [--lang go|py]
+//	aoc fetch <days> [--force]
+//	aoc submit <day> <part> [answer]
+//
+// where days is a day number, a range like 3-7, a comma-separated list of
+// those, or "all".
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// stdout is where the commands write their results. It's kept apart from
+// os.Stdout, which gets thrown away while the parts run.
+var stdout io.Writer = os.Stdout
+
+type command struct {
+	usage string
+	run   func(args []string) error
+}
+
+var commands = map[string]command{
+	"run":    {"run <days> [--input name] [--timeout d] [-v] [args...]", runCmd},
+	"check":  {"check <days> [--input name] [--timeout d] [-v]", checkCmd},
+	"bench":  {"bench <days> [--input name] [--count n] [--format md|json] [-o file] [--timeout d]", benchCmd},
+	"new":    {"new <day> [--lang go|py]", newCmd},
+	"fetch":  {"fetch <days> [--force] [--url u] [--session s] [--year y]", fetchCmd},
+	"submit": {"submit <day> <part> [answer] [--input name] [--url u] [--session s] [--year y]", submitCmd},
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  aoc %s\n", commands[name].usage)
+	}
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		usage()
+		os.Exit(2)
+	}
+	if err := cmd.run(os.Args[2:]); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, "aoc:", err)
+		}
+		os.Exit(1)
+	}
+}
+
+// parseArgs parses flags that may come before, after or between the
+// positional arguments, so "run 6 --input sample" works as well as
+// "run --input sample 6".
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// findRoot walks up from the current directory to the one holding go.work.
+func findRoot() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.work")); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", errors.New("can't find go.work above the current directory; use --root")
+		}
+		dir = parent
+	}
+}
+
+// dayDir returns the directory holding a day's module.
+func dayDir(root string, day int) string {
+	return filepath.Join(root, fmt.Sprintf("day%02d_go", day))
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// newCmd starts a new day from the _template_<lang> directory. A Go day is
+// also added to go.work and to the runner, so "aoc run" can find it right
+// away.
+func newCmd(args []string) error {
+	flags := flag.NewFlagSet("new", flag.ContinueOnError)
+	lang := flags.String("lang", "go", "language of the template to use: go or py")
+	root := flags.String("root", "", "repository root (default: the directory holding go.work)")
+	positional, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return errors.New("new: which day? (a number from 1 to 25)")
+	}
+	day, err := strconv.Atoi(positional[0])
+	if err != nil || day < 1 || day > 25 {
+		return fmt.Errorf("new: bad day %q", positional[0])
+	}
+	if *root == "" {
+		if *root, err = findRoot(); err != nil {
+			return err
+		}
+	}
+	dir, err := newDay(*root, day, *lang)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(stdout, "created", dir)
+	return nil
+}
+
+// templateData is what the template files can refer to.
+type templateData struct {
+	Day     int    // 6
+	Package string // day06
+}
+
+// newDay makes the directory for a day, filled in from the template, and
+// returns its path. It won't touch a day that already exists.
+func newDay(root string, day int, lang string) (string, error) {
+	tmpl := filepath.Join(root, "_template_"+lang)
+	if _, err := os.Stat(tmpl); err != nil {
+		return "", fmt.Errorf("no template for language %q", lang)
+	}
+	data := templateData{Day: day, Package: fmt.Sprintf("day%02d", day)}
+	dir := filepath.Join(root, fmt.Sprintf("%s_%s", data.Package, lang))
+	if _, err := os.Stat(dir); err == nil {
+		return "", fmt.Errorf("%s already exists", dir)
+	}
+
+	// work out all the edits before writing anything, so a problem with one
+	// of them doesn't leave a half-made day behind
+	var edits map[string]string
+	if lang == "go" {
+		var err error
+		if edits, err = registerDay(root, data.Package); err != nil {
+			return "", err
+		}
+	}
+	if err := copyTemplate(tmpl, dir, data); err != nil {
+		os.RemoveAll(dir)
+		return "", err
+	}
+	for path, contents := range edits {
+		if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+			return "", err
+		}
+	}
+	return dir, nil
+}
+
+// copyTemplate copies every file in the template directory to dir, running
+// each through text/template and replacing XXX in its name with the package
+// name.
+func copyTemplate(tmpl, dir string, data templateData) error {
+	return filepath.WalkDir(tmpl, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(tmpl, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dir, strings.ReplaceAll(rel, "XXX", data.Package))
+		if d.IsDir() {
+			return os.MkdirAll(target, 0o755)
+		}
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		t, err := template.New(rel).Parse(string(src))
+		if err != nil {
+			return err
+		}
+		var buf bytes.Buffer
+		if err := t.Execute(&buf, data); err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, buf.Bytes(), info.Mode().Perm())
+	})
+}
+
+// registerDay returns the new contents of go.work, the runner's go.mod and
+// its list of days, with the new day's module added to each.
+func registerDay(root, pkg string) (map[string]string, error) {
+	module := "github.com/kentquirk/aoc2024/" + pkg
+	edits := []struct {
+		path  string
+		block string
+		lines []string
+	}{
+		{"go.work", "use (", []string{"./" + pkg + "_go"}},
+		{"cmd/aoc/go.mod", "require (", []string{module + " v0.0.0"}},
+		{"cmd/aoc/go.mod", "replace (", []string{module + " => ../../" + pkg + "_go"}},
+		{"cmd/aoc/days.go", "import (", []string{`_ "` + module + `"`}},
+	}
+	files := make(map[string]string)
+	for _, e := range edits {
+		path := filepath.Join(root, e.path)
+		src, ok := files[path]
+		if !ok {
+			b, err := os.ReadFile(path)
+			if err != nil {
+				return nil, err
+			}
+			src = string(b)
+		}
+		for _, line := range e.lines {
+			var err error
+			if src, err = addToBlock(src, e.block, line); err != nil {
+				return nil, fmt.Errorf("%s: %w", e.path, err)
+			}
+		}
+		files[path] = src
+	}
+	return files, nil
+}
+
+// addToBlock adds a line to a parenthesized block like "use (" in go.work or
+// "import (" in a Go file, keeping the block in sorted order.
+func addToBlock(src, block, line string) (string, error) {
+	lines := strings.Split(src, "\n")
+	start := -1
+	for i, l := range lines {
+		if strings.TrimSpace(l) == block {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		return "", fmt.Errorf("no %q block", block)
+	}
+	at := -1
+	for i := start + 1; i < len(lines); i++ {
+		entry := strings.TrimSpace(lines[i])
+		if entry == ")" {
+			at = i
+			break
+		}
+		if entry == line {
+			return "", fmt.Errorf("%s is already there", line)
+		}
+		if entry > line {
+			at = i
+			break
+		}
+	}
+	if at < 0 {
+		return "", fmt.Errorf("%q block isn't closed", block)
+	}
+	lines = append(lines[:at], append([]string{"\t" + line}, lines[at:]...)...)
+	return strings.Join(lines, "\n"), nil
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_addToBlock(t *testing.T) {
+	src := "go 1.23\n\nuse (\n\t.\n\t./day01_go\n\t./day03_go\n)\n"
+	tests := []struct {
+		name    string
+		line    string
+		want    string
+		wantErr bool
+	}{
+		{"middle", "./day02_go", "go 1.23\n\nuse (\n\t.\n\t./day01_go\n\t./day02_go\n\t./day03_go\n)\n", false},
+		{"end", "./day25_go", "go 1.23\n\nuse (\n\t.\n\t./day01_go\n\t./day03_go\n\t./day25_go\n)\n", false},
+		{"already there", "./day03_go", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := addToBlock(src, "use (", tt.line)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("addToBlock() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("addToBlock() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+	if _, err := addToBlock(src, "import (", "x"); err == nil {
+		t.Error("addToBlock() should fail without the block")
+	}
+}
+
+// fakeRoot makes a repository with just enough in it for newDay: a template
+// and the files that a new day gets added to.
+func fakeRoot(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	files := map[string]string{
+		"go.work":                     "go 1.23\n\nuse (\n\t.\n\t./cmd/aoc\n\t./day01_go\n)\n",
+		"cmd/aoc/go.mod":              "module x\n\nrequire (\n\tgithub.com/kentquirk/aoc2024/day01 v0.0.0\n)\n\nreplace (\n\tgithub.com/kentquirk/aoc2024/day01 => ../../day01_go\n)\n",
+		"cmd/aoc/days.go":             "package main\n\nimport (\n\t_ \"github.com/kentquirk/aoc2024/day01\"\n)\n",
+		"_template_go/XXX.go":         "package {{.Package}}\n\n// Register({{.Day}})\n",
+		"_template_go/data/x.txt":     "1\n2\n",
+		"_template_py/main.py":        "print(1)\n",
+		"_template_py/data/input.txt": "",
+	}
+	for name, contents := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func Test_newDay(t *testing.T) {
+	root := fakeRoot(t)
+	dir, err := newDay(root, 7, "go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(root, "day07_go"); dir != want {
+		t.Errorf("newDay() = %s, want %s", dir, want)
+	}
+	if got, want := readFile(t, filepath.Join(dir, "day07.go")), "package day07\n\n// Register(7)\n"; got != want {
+		t.Errorf("day07.go = %q, want %q", got, want)
+	}
+	if got := readFile(t, filepath.Join(dir, "data/x.txt")); got != "1\n2\n" {
+		t.Errorf("data file = %q", got)
+	}
+	wired := []struct {
+		file string
+		want string
+	}{
+		{"go.work", "\t./day01_go\n\t./day07_go\n)"},
+		{"cmd/aoc/go.mod", "\tgithub.com/kentquirk/aoc2024/day07 v0.0.0\n)"},
+		{"cmd/aoc/go.mod", "\tgithub.com/kentquirk/aoc2024/day07 => ../../day07_go\n)"},
+		{"cmd/aoc/days.go", "\t_ \"github.com/kentquirk/aoc2024/day07\"\n)"},
+	}
+	for _, w := range wired {
+		if got := readFile(t, filepath.Join(root, w.file)); !strings.Contains(got, w.want) {
+			t.Errorf("%s doesn't have the new day:\n%s", w.file, got)
+		}
+	}
+
+	if _, err := newDay(root, 7, "go"); err == nil {
+		t.Error("newDay() should refuse to overwrite an existing day")
+	}
+
+	// python days are just copied
+	before := readFile(t, filepath.Join(root, "go.work"))
+	if _, err := newDay(root, 8, "py"); err != nil {
+		t.Fatal(err)
+	}
+	if got := readFile(t, filepath.Join(root, "day08_py/main.py")); got != "print(1)\n" {
+		t.Errorf("main.py = %q", got)
+	}
+	if after := readFile(t, filepath.Join(root, "go.work")); after != before {
+		t.Errorf("a python day changed go.work:\n%s", after)
+	}
+
+	if _, err := newDay(root, 9, "ts"); err == nil {
+		t.Error("newDay() should fail without a template")
+	}
+}
+
+func Test_newDayLeavesNothingBehind(t *testing.T) {
+	root := fakeRoot(t)
+	// day 1 is already wired up, even though its directory is missing
+	if _, err := newDay(root, 1, "go"); err == nil {
+		t.Fatal("newDay() should fail when the day is already registered")
+	}
+	if _, err := os.Stat(filepath.Join(root, "day01_go")); err == nil {
+		t.Error("newDay() left a directory behind")
+	}
+}
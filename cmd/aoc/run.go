@@ -0,0 +1,226 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kentquirk/aoc2024/aoc"
+)
+
+type result struct {
+	day     int
+	part    int
+	answer  any
+	err     error
+	elapsed time.Duration
+}
+
+func runCmd(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	input := fs.String("input", "sample", "name of the input file in each day's data directory")
+	root := fs.String("root", "", "repository root (default: the directory holding go.work)")
+	timeout := fs.Duration("timeout", 0, "stop waiting for a part after this long; the part isn't killed, only asked to stop (0 means wait forever)")
+	verbose := fs.Bool("v", false, "show the solutions' own output")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 {
+		return errors.New("run: which days? (a number, a range like 1-5, or all)")
+	}
+	if *root == "" {
+		if *root, err = findRoot(); err != nil {
+			return err
+		}
+	}
+	days, err := parseDays(positional[0], registeredDays())
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "%-4s %-4s %-24s %12s\n", "day", "part", "answer", "time")
+	failed := 0
+	var total time.Duration
+	for _, day := range days {
+		for _, r := range runDay(*root, day, *input, positional[1:], []int{1, 2}, *timeout, *verbose) {
+			total += r.elapsed
+			answer := fmt.Sprint(r.answer)
+			if r.err != nil {
+				failed++
+				answer = "FAIL: " + r.err.Error()
+			}
+			fmt.Fprintf(stdout, "%-4d %-4d %-24s %12v\n", r.day, r.part, answer, r.elapsed.Round(time.Microsecond))
+		}
+	}
+	fmt.Fprintf(stdout, "%-34s %12v\n", "total", total.Round(time.Microsecond))
+	if failed > 0 {
+		return fmt.Errorf("%d part(s) failed", failed)
+	}
+	return nil
+}
+
+// runDay runs the given parts of one day against the named input.
+func runDay(root string, day int, name string, args []string, parts []int, timeout time.Duration, verbose bool) []result {
+	s, _ := aoc.Lookup(day)
+	results := make([]result, len(parts))
+	in, err := loadInput(root, day, name, args)
+	for i, part := range parts {
+		if err != nil {
+			results[i] = result{day: day, part: part, err: err}
+			continue
+		}
+		f := s.Part1
+		if part == 2 {
+			f = s.Part2
+		}
+		results[i] = runPart(day, part, f, in, timeout, verbose)
+	}
+	return results
+}
+
+func loadInput(root string, day int, name string, args []string) (*aoc.Input, error) {
+	if name == aoc.Stdin {
+		return aoc.NewInput(name, os.Stdin, args...)
+	}
+	f, err := os.Open(filepath.Join(dayDir(root, day), aoc.DataFile(name)))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return aoc.NewInput(name, f, args...)
+}
+
+// runPart runs one part, turning a panic into an error. Unless verbose is
+// set, anything the part prints to stdout is thrown away. A part that runs
+// past the timeout can't be killed, so it's left running with in.Done
+// closed, to give it the chance to stop, and stdout stays thrown away until
+// it does.
+func runPart(day, part int, f func(*aoc.Input) (any, error), in *aoc.Input, timeout time.Duration, verbose bool) result {
+	release := func() {}
+	if !verbose {
+		release = hush()
+	}
+	stop := make(chan struct{})
+	run := *in
+	run.Done = stop
+
+	done := make(chan result, 1)
+	start := time.Now()
+	go func() {
+		r := result{day: day, part: part}
+		defer func() {
+			if p := recover(); p != nil {
+				r.err = fmt.Errorf("panic: %v", p)
+			}
+			r.elapsed = time.Since(start)
+			release()
+			done <- r
+		}()
+		r.answer, r.err = f(&run)
+	}()
+
+	if timeout <= 0 {
+		return <-done
+	}
+	select {
+	case r := <-done:
+		return r
+	case <-time.After(timeout):
+		close(stop)
+		return result{day: day, part: part, err: fmt.Errorf("timed out after %v", timeout), elapsed: timeout}
+	}
+}
+
+// quiet throws away stdout while any part is running without -v, counting
+// the ones that timed out but haven't stopped yet. The parts' output all
+// goes through the same os.Stdout, so it can only be put back once the last
+// of them is done.
+var quiet struct {
+	sync.Mutex
+	running int
+	restore func()
+}
+
+// hush throws away stdout until release has been called as many times as
+// hush has.
+func hush() (release func()) {
+	quiet.Lock()
+	defer quiet.Unlock()
+	if quiet.running == 0 {
+		quiet.restore = aoc.Quiet()
+	}
+	quiet.running++
+	return func() {
+		quiet.Lock()
+		defer quiet.Unlock()
+		if quiet.running--; quiet.running == 0 {
+			quiet.restore()
+		}
+	}
+}
+
+func registeredDays() []int {
+	var days []int
+	for _, d := range aoc.Days() {
+		days = append(days, d.Number)
+	}
+	return days
+}
+
+// puzzleDays is every day of the calendar, whether it's been solved or not.
+func puzzleDays() []int {
+	days := make([]int, 25)
+	for i := range days {
+		days[i] = i + 1
+	}
+	return days
+}
+
+// parseDays turns a spec like "all", "6", "1-5" or "1,3,10-12" into the
+// list of registered days it names.
+func parseDays(spec string, available []int) ([]int, error) {
+	if spec == "all" {
+		return available, nil
+	}
+	registered := make(map[int]bool)
+	for _, d := range available {
+		registered[d] = true
+	}
+	wanted := make(map[int]bool)
+	for _, item := range strings.Split(spec, ",") {
+		lo, hi, isRange := strings.Cut(item, "-")
+		first, err := strconv.Atoi(lo)
+		if err != nil {
+			return nil, fmt.Errorf("bad day %q", item)
+		}
+		last := first
+		if isRange {
+			if last, err = strconv.Atoi(hi); err != nil || last < first {
+				return nil, fmt.Errorf("bad day range %q", item)
+			}
+		}
+		if !isRange && !registered[first] {
+			return nil, fmt.Errorf("day %d has no registered solution", first)
+		}
+		for d := first; d <= last; d++ {
+			wanted[d] = true
+		}
+	}
+	var days []int
+	for _, d := range available {
+		if wanted[d] {
+			days = append(days, d)
+		}
+	}
+	if len(days) == 0 {
+		return nil, fmt.Errorf("no registered days in %q", spec)
+	}
+	return days, nil
+}
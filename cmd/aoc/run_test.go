@@ -0,0 +1,118 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kentquirk/aoc2024/aoc"
+)
+
+func Test_parseDays(t *testing.T) {
+	available := []int{1, 2, 3, 5, 6, 25}
+	tests := []struct {
+		name    string
+		spec    string
+		want    []int
+		wantErr bool
+	}{
+		{"all", "all", available, false},
+		{"single", "6", []int{6}, false},
+		{"range", "2-5", []int{2, 3, 5}, false},
+		{"range skips gaps", "4-4", nil, true},
+		{"list", "25,1,3", []int{1, 3, 25}, false},
+		{"mixed", "1,5-30", []int{1, 5, 6, 25}, false},
+		{"missing day", "4", nil, true},
+		{"backwards range", "5-2", nil, true},
+		{"junk", "x", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDays(tt.spec, available)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseDays() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseDays() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_runPart(t *testing.T) {
+	in := &aoc.Input{Name: "test", Lines: []string{"1", "2"}}
+	tests := []struct {
+		name    string
+		part    func(*aoc.Input) (any, error)
+		want    any
+		wantErr bool
+	}{
+		{"answer", func(in *aoc.Input) (any, error) { return len(in.Lines), nil }, 2, false},
+		{"error", func(*aoc.Input) (any, error) { return nil, errors.New("nope") }, nil, true},
+		{"panic", func(*aoc.Input) (any, error) { panic("boom") }, nil, true},
+		{"timeout", func(in *aoc.Input) (any, error) {
+			select {
+			case <-in.Done:
+				return nil, aoc.ErrStopped
+			case <-time.After(time.Second):
+				return 1, nil
+			}
+		}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := runPart(1, 1, tt.part, in, 50*time.Millisecond, false)
+			if (r.err != nil) != tt.wantErr {
+				t.Fatalf("runPart() error = %v, wantErr %v", r.err, tt.wantErr)
+			}
+			if r.answer != tt.want {
+				t.Errorf("runPart() = %v, want %v", r.answer, tt.want)
+			}
+		})
+	}
+}
+
+func Test_runPart_timeout(t *testing.T) {
+	quiet := make(chan bool)
+	part := func(in *aoc.Input) (any, error) {
+		<-in.Done
+		quiet <- io.Writer(os.Stdout) != stdout
+		return nil, aoc.ErrStopped
+	}
+	if r := runPart(1, 1, part, &aoc.Input{}, 10*time.Millisecond, false); r.err == nil {
+		t.Fatal("runPart() didn't time out")
+	}
+	select {
+	case q := <-quiet:
+		if !q {
+			t.Error("stdout was put back while the part was still running")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the part was never told to stop")
+	}
+	for deadline := time.Now().Add(time.Second); io.Writer(os.Stdout) != stdout; time.Sleep(time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("stdout wasn't put back after the part stopped")
+		}
+	}
+}
+
+func Test_parseArgs(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	input := fs.String("input", "sample", "")
+	verbose := fs.Bool("v", false, "")
+	positional, err := parseArgs(fs, []string{"6", "--input", "input", "70", "-v", "1024"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"6", "70", "1024"}; !reflect.DeepEqual(positional, want) {
+		t.Errorf("parseArgs() = %v, want %v", positional, want)
+	}
+	if *input != "input" || !*verbose {
+		t.Errorf("flags not parsed: input=%q verbose=%v", *input, *verbose)
+	}
+}
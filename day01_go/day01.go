@@ -0,0 +1,177 @@
+package day01
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kentquirk/aoc2024/aoc"
+)
+
+// lists holds columns of numbers as counts of each value rather than the
+// values themselves, so a long input that repeats itself doesn't take much
+// room. Only the columns that were asked for are kept.
+type lists struct {
+	width   int // how many columns each row has
+	rows    int
+	columns []int               // the columns kept, counted from 0
+	counts  map[int]map[int]int // column -> value -> how many times it appears
+}
+
+func newLists(columns ...int) *lists {
+	l := &lists{columns: columns, counts: make(map[int]map[int]int)}
+	for _, c := range columns {
+		l.counts[c] = make(map[int]int)
+	}
+	return l
+}
+
+// add counts one line of whitespace-separated numbers. Blank lines are
+// skipped; every other row has to have the same number of columns as the
+// first one.
+func (l *lists) add(lineno int, line string) error {
+	if strings.TrimSpace(line) == "" {
+		return nil
+	}
+	row, err := aoc.Extractor{Strict: true}.Ints(line)
+	if err != nil {
+		var ne *aoc.NumberError
+		if errors.As(err, &ne) {
+			ne.Line = lineno
+		}
+		return err
+	}
+	if l.rows == 0 {
+		l.width = len(row)
+		for _, c := range l.columns {
+			if c < 0 || c >= l.width {
+				return fmt.Errorf("line %d: there's no column %d in a row of %d", lineno, c+1, l.width)
+			}
+		}
+	}
+	if len(row) != l.width {
+		return fmt.Errorf("line %d: expected %d numbers, got %d", lineno, l.width, len(row))
+	}
+	for c, counts := range l.counts {
+		counts[row[c]]++
+	}
+	l.rows++
+	return nil
+}
+
+// readLists reads rows of numbers a line at a time, keeping the given
+// columns (counted from 0), so the input never has to be held in memory
+func readLists(r io.Reader, columns ...int) (*lists, error) {
+	l := newLists(columns...)
+	scanner := bufio.NewScanner(r)
+	for lineno := 1; scanner.Scan(); lineno++ {
+		if err := l.add(lineno, scanner.Text()); err != nil {
+			return nil, err
+		}
+	}
+	return l, scanner.Err()
+}
+
+// sorted returns the distinct values in a column in order
+func (l *lists) sorted(c int) []int {
+	values := make([]int, 0, len(l.counts[c]))
+	for v := range l.counts[c] {
+		values = append(values, v)
+	}
+	slices.Sort(values)
+	return values
+}
+
+// distance pairs up the smallest value in column a with the smallest in b,
+// and so on, and adds up how far apart each pair is
+func (l *lists) distance(a, b int) int {
+	as, bs := l.sorted(a), l.sorted(b)
+	// how many of the current value in each column haven't been paired yet
+	aLeft, bLeft := 0, 0
+	dist := 0
+	for i, j := 0, 0; i < len(as) && j < len(bs); {
+		if aLeft == 0 {
+			aLeft = l.counts[a][as[i]]
+		}
+		if bLeft == 0 {
+			bLeft = l.counts[b][bs[j]]
+		}
+		n := min(aLeft, bLeft)
+		d := as[i] - bs[j]
+		if d < 0 {
+			d = -d
+		}
+		dist += n * d
+		if aLeft -= n; aLeft == 0 {
+			i++
+		}
+		if bLeft -= n; bLeft == 0 {
+			j++
+		}
+	}
+	return dist
+}
+
+// similarity adds up each value in column a times the number of times it
+// appears in column b
+func (l *lists) similarity(a, b int) int {
+	score := 0
+	for v, n := range l.counts[a] {
+		score += v * n * l.counts[b][v]
+	}
+	return score
+}
+
+// columnArgs returns the two columns to compare: the first two unless the
+// arguments say otherwise, as in "aoc run 1 --input input 1 3". Columns on
+// the command line are counted from 1.
+func columnArgs(args []string) (int, int, error) {
+	cols := []int{1, 2}
+	for i, arg := range args {
+		if i >= 2 {
+			return 0, 0, fmt.Errorf("expected at most two columns, got %v", args)
+		}
+		c, err := strconv.Atoi(arg)
+		if err != nil || c < 1 {
+			return 0, 0, fmt.Errorf("bad column %q", arg)
+		}
+		cols[i] = c
+	}
+	return cols[0] - 1, cols[1] - 1, nil
+}
+
+func part1(r io.Reader, a, b int) (int, error) {
+	l, err := readLists(r, a, b)
+	if err != nil {
+		return 0, err
+	}
+	return l.distance(a, b), nil
+}
+
+func part2(r io.Reader, a, b int) (int, error) {
+	l, err := readLists(r, a, b)
+	if err != nil {
+		return 0, err
+	}
+	return l.similarity(a, b), nil
+}
+
+// onColumns adapts a part to read the input as a stream, with the columns
+// from the arguments
+func onColumns(f func(r io.Reader, a, b int) (int, error)) aoc.Part {
+	return func(in *aoc.Input) (any, error) {
+		a, b, err := columnArgs(in.Args)
+		if err != nil {
+			return nil, err
+		}
+		return f(strings.NewReader(in.Text), a, b)
+	}
+}
+
+func init() {
+	aoc.Register(1, aoc.Parts{onColumns(part1), onColumns(part2)})
+}
@@ -0,0 +1,85 @@
+package day01
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kentquirk/aoc2024/aoc/aoctest"
+)
+
+func TestAnswers(t *testing.T) {
+	aoctest.Answers(t, 1)
+}
+
+func BenchmarkParts(b *testing.B) {
+	aoctest.Benchmark(b, 1)
+}
+
+func Test_parts(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		a, b     int
+		wantDist int
+		wantSim  int
+	}{
+		{"sample", "3   4\n4   3\n2   5\n1   3\n3   9\n3   3\n", 0, 1, 11, 31},
+		{"trailing blank line", "3   4\n4   3\n\n", 0, 1, 0, 7},
+		{"three columns", "1 9 1\n2 8 2\n2 7 3\n", 0, 2, 1, 5},
+		{"other way round", "1 9 1\n2 8 2\n2 7 3\n", 2, 0, 1, 5},
+		{"no rows", "", 0, 1, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dist, err := part1(strings.NewReader(tt.input), tt.a, tt.b)
+			if err != nil || dist != tt.wantDist {
+				t.Errorf("part1() = %v, %v, want %v", dist, err, tt.wantDist)
+			}
+			sim, err := part2(strings.NewReader(tt.input), tt.a, tt.b)
+			if err != nil || sim != tt.wantSim {
+				t.Errorf("part2() = %v, %v, want %v", sim, err, tt.wantSim)
+			}
+		})
+	}
+}
+
+func Test_readListsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{"short row", "1 2\n3\n", "line 2: expected 2 numbers, got 1"},
+		{"junk", "1 2\n\n3 4x\n", "line 3"},
+		{"no such column", "1\n", "line 1: there's no column 2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := readLists(strings.NewReader(tt.input), 0, 1)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("readLists() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_columnArgs(t *testing.T) {
+	tests := []struct {
+		args    []string
+		a, b    int
+		wantErr bool
+	}{
+		{nil, 0, 1, false},
+		{[]string{"3"}, 2, 1, false},
+		{[]string{"1", "3"}, 0, 2, false},
+		{[]string{"0"}, 0, 0, true},
+		{[]string{"x"}, 0, 0, true},
+		{[]string{"1", "2", "3"}, 0, 0, true},
+	}
+	for _, tt := range tests {
+		a, b, err := columnArgs(tt.args)
+		if (err != nil) != tt.wantErr || (!tt.wantErr && (a != tt.a || b != tt.b)) {
+			t.Errorf("columnArgs(%v) = %d, %d, %v", tt.args, a, b, err)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package day02
+
+import (
+	"fmt"
+
+	"github.com/kentquirk/aoc2024/aoc"
+)
+
+// direction is which way the levels in a report are allowed to go
+type direction int
+
+const (
+	either direction = iota // all up or all down, whichever the report starts with
+	up
+	down
+)
+
+// rules says what makes a report safe. Each step between neighbouring levels
+// has to be between minStep and maxStep in size, and they all have to go the
+// same way. dampen is how many levels the Problem Dampener may drop.
+type rules struct {
+	minStep, maxStep int
+	dir              direction
+	dampen           int
+}
+
+var reactorRules = rules{minStep: 1, maxStep: 3, dir: either}
+
+// verdict is what a check found out about one report.
+type verdict struct {
+	safe bool
+	// removed is the index of each level the dampener dropped to make the
+	// report safe, if it had to
+	removed []int
+	// for an unsafe report, level is the index of the first level that broke
+	// the rules, and reason says how
+	level  int
+	reason string
+}
+
+func (v verdict) String() string {
+	switch {
+	case !v.safe:
+		return fmt.Sprintf("unsafe: level %d %s", v.level+1, v.reason)
+	case len(v.removed) > 0:
+		s := "safe without level"
+		if len(v.removed) > 1 {
+			s += "s"
+		}
+		for i, r := range v.removed {
+			if i > 0 {
+				s += ","
+			}
+			s += fmt.Sprintf(" %d", r+1)
+		}
+		return s
+	}
+	return "safe"
+}
+
+// step reports why going from a to b breaks the rules for a report going in
+// dir, or "" if it doesn't. The rules either say which way to go or leave
+// it to the report's first step, and the reason says which.
+func (r rules) step(a, b int, dir direction) string {
+	delta := b - a
+	size := delta
+	if size < 0 {
+		size = -size
+	}
+	went := "after going"
+	if r.dir != either {
+		went = "but must go"
+	}
+	switch {
+	case dir == up && delta < 0:
+		return fmt.Sprintf("(%d) goes down %s up", b, went)
+	case dir == down && delta > 0:
+		return fmt.Sprintf("(%d) goes up %s down", b, went)
+	case size < r.minStep:
+		return fmt.Sprintf("(%d) changes by %d, less than %d", b, size, r.minStep)
+	case size > r.maxStep:
+		return fmt.Sprintf("(%d) changes by %d, more than %d", b, size, r.maxStep)
+	}
+	return ""
+}
+
+// firstProblem finds the first level that breaks the rules when nothing is
+// dropped. It returns -1 if there isn't one.
+func (r rules) firstProblem(levels []int) (int, string) {
+	dir := r.dir
+	for i := 1; i < len(levels); i++ {
+		if dir == either {
+			dir = up
+			if levels[i] < levels[i-1] {
+				dir = down
+			}
+		}
+		if why := r.step(levels[i-1], levels[i], dir); why != "" {
+			return i, why
+		}
+	}
+	return -1, ""
+}
+
+// fewestRemovals works out the fewest levels to drop to make the report safe
+// going in dir, and which ones. Only the last dampen+1 levels kept can be the
+// one before any level, so this is linear in the length of the report.
+func (r rules) fewestRemovals(levels []int, dir direction) (int, []int) {
+	n := len(levels)
+	// cost[i] is the fewest levels to drop before i if i is kept, and
+	// prev[i] is the level kept before it (-1 if none)
+	cost := make([]int, n)
+	prev := make([]int, n)
+	best, last := n, -1
+	for i := range levels {
+		cost[i], prev[i] = i, -1
+		for j := i - 1; j >= 0 && j >= i-1-r.dampen; j-- {
+			if c := cost[j] + i - j - 1; c < cost[i] && r.step(levels[j], levels[i], dir) == "" {
+				cost[i], prev[i] = c, j
+			}
+		}
+		if c := cost[i] + n - 1 - i; c < best {
+			best, last = c, i
+		}
+	}
+	if last < 0 {
+		return 0, nil
+	}
+
+	kept := make([]bool, n)
+	for i := last; i >= 0; i = prev[i] {
+		kept[i] = true
+	}
+	var removed []int
+	for i, k := range kept {
+		if !k {
+			removed = append(removed, i)
+		}
+	}
+	return best, removed
+}
+
+// check decides whether a report is safe, dropping up to r.dampen levels if
+// that helps.
+func (r rules) check(levels []int) verdict {
+	level, why := r.firstProblem(levels)
+	if level < 0 {
+		return verdict{safe: true, level: -1}
+	}
+	if r.dampen > 0 {
+		dirs := []direction{r.dir}
+		if r.dir == either {
+			dirs = []direction{up, down}
+		}
+		for _, dir := range dirs {
+			if n, removed := r.fewestRemovals(levels, dir); n <= r.dampen {
+				return verdict{safe: true, removed: removed, level: -1}
+			}
+		}
+	}
+	return verdict{level: level, reason: why}
+}
+
+// countSafe counts the safe reports. Each report's verdict is printed so
+// the results can be checked with "aoc run -v". A line with no levels
+// isn't a report, so it's skipped rather than counted as safe.
+func countSafe(lines []string, r rules) int {
+	nsafe := 0
+	for i, line := range lines {
+		levels := aoc.Ints(line)
+		if len(levels) == 0 {
+			continue
+		}
+		v := r.check(levels)
+		if v.safe {
+			nsafe++
+		}
+		fmt.Printf("line %d: %v\n", i+1, v)
+	}
+	return nsafe
+}
+
+func part1(lines []string) int {
+	return countSafe(lines, reactorRules)
+}
+
+func part2(lines []string) int {
+	r := reactorRules
+	r.dampen = 1
+	return countSafe(lines, r)
+}
+
+func init() {
+	aoc.Register(2, aoc.Parts{aoc.OnLines(part1), aoc.OnLines(part2)})
+}
@@ -0,0 +1,58 @@
+package day02
+
+import (
+	"testing"
+
+	"github.com/kentquirk/aoc2024/aoc/aoctest"
+)
+
+func TestAnswers(t *testing.T) {
+	aoctest.Answers(t, 2)
+}
+
+func BenchmarkParts(b *testing.B) {
+	aoctest.Benchmark(b, 2)
+}
+
+func Test_rules_check(t *testing.T) {
+	dampen := func(k int) rules {
+		r := reactorRules
+		r.dampen = k
+		return r
+	}
+	tests := []struct {
+		name   string
+		rules  rules
+		levels []int
+		want   string
+	}{
+		{"safe down", reactorRules, []int{7, 6, 4, 2, 1}, "safe"},
+		{"big jump", reactorRules, []int{1, 2, 7, 8, 9}, "unsafe: level 3 (7) changes by 5, more than 3"},
+		{"turns", reactorRules, []int{1, 3, 2, 4, 5}, "unsafe: level 3 (2) goes down after going up"},
+		{"flat", reactorRules, []int{8, 6, 4, 4, 1}, "unsafe: level 4 (4) changes by 0, less than 1"},
+		{"dampened turn", dampen(1), []int{1, 3, 2, 4, 5}, "safe without level 2"},
+		{"dampened flat", dampen(1), []int{8, 6, 4, 4, 1}, "safe without level 3"},
+		{"first level bad", dampen(1), []int{9, 1, 2, 3}, "safe without level 1"},
+		{"last level bad", dampen(1), []int{1, 2, 3, 9}, "safe without level 4"},
+		{"too much for one", dampen(1), []int{1, 2, 7, 8, 9}, "unsafe: level 3 (7) changes by 5, more than 3"},
+		{"two will do", dampen(2), []int{1, 2, 7, 8, 3, 4}, "safe without levels 3, 4"},
+		{"only up", rules{minStep: 1, maxStep: 3, dir: up}, []int{7, 6, 4}, "unsafe: level 2 (6) goes down but must go up"},
+		{"only down", rules{minStep: 1, maxStep: 3, dir: down}, []int{4, 3, 5}, "unsafe: level 3 (5) goes up but must go down"},
+		{"wide steps", rules{minStep: 2, maxStep: 10, dir: either}, []int{1, 3, 13}, "safe"},
+		{"one level", reactorRules, []int{5}, "safe"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rules.check(tt.levels).String(); got != tt.want {
+				t.Errorf("check() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_countSafe(t *testing.T) {
+	lines := []string{"7 6 4 2 1", "", "1 2 7 8 9", "  ", "1 3 6 7 9", ""}
+	if got := countSafe(lines, reactorRules); got != 2 {
+		t.Errorf("countSafe() = %d, want 2", got)
+	}
+}
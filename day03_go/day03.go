@@ -0,0 +1,202 @@
+package day03
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/kentquirk/aoc2024/aoc"
+)
+
+// maxDigits is the most digits an operand can have
+const maxDigits = 3
+
+// token is one instruction found in the memory. If it couldn't be read, it
+// has the reason in err and no args.
+type token struct {
+	offset int64  // where the instruction starts, in bytes
+	text   string // as far as it could be read
+	name   string
+	args   []int
+	err    string
+}
+
+func (t token) String() string {
+	if t.err != "" {
+		return fmt.Sprintf("%q: %s", t.text, t.err)
+	}
+	return t.text
+}
+
+func (t token) reject(text []byte, why string) token {
+	t.text, t.args, t.err = string(text), nil, why
+	return t
+}
+
+// handler carries out an instruction. It returns false if the instruction
+// was ignored.
+type handler func(c *computer, args []int) bool
+
+// instruction is something the computer knows how to do: its name, how many
+// operands it takes, and what it does with them
+type instruction struct {
+	name    string
+	arity   int
+	handler handler
+}
+
+// computer runs the instructions it can find in corrupted memory and ignores
+// everything else.
+type computer struct {
+	instrs  map[byte][]instruction // by the first byte of the name
+	longest int                    // the most bytes an instruction can take up
+	// trace, if it's set, gets a line for every instruction seen, with its
+	// offset in bytes from the start of the memory
+	trace io.Writer
+
+	enabled bool
+	total   int
+}
+
+func newComputer() *computer {
+	return &computer{instrs: make(map[byte][]instruction), enabled: true}
+}
+
+// register teaches the computer a new instruction. It panics on a name that
+// can't be found in memory.
+func (c *computer) register(name string, arity int, handler handler) {
+	if name == "" || strings.ContainsAny(name, "(),") {
+		panic(fmt.Sprintf("bad instruction name %q", name))
+	}
+	c.instrs[name[0]] = append(c.instrs[name[0]], instruction{name, arity, handler})
+	// the name, the parens, and each operand with a comma or paren after it
+	c.longest = max(c.longest, len(name)+2+arity*(maxDigits+1))
+}
+
+// lex tries to read an instruction at the start of b. It returns the token
+// and how many bytes it used, or 0 if b doesn't start with the name of an
+// instruction.
+func (c *computer) lex(b []byte, offset int64) (token, int, *instruction) {
+	for i := range c.instrs[b[0]] {
+		ins := &c.instrs[b[0]][i]
+		n := len(ins.name)
+		if len(b) <= n || string(b[:n]) != ins.name || b[n] != '(' {
+			continue
+		}
+		tok := token{offset: offset, name: ins.name}
+		p := n + 1
+		for arg := 0; arg < ins.arity; arg++ {
+			if arg > 0 {
+				if p >= len(b) || b[p] != ',' {
+					return tok.reject(b[:p], "expected ','"), p, nil
+				}
+				p++
+			}
+			v, digits := 0, 0
+			for ; p < len(b) && b[p] >= '0' && b[p] <= '9'; p++ {
+				v = v*10 + int(b[p]-'0')
+				digits++
+			}
+			switch {
+			case digits == 0:
+				return tok.reject(b[:p], "expected a number"), p, nil
+			case digits > maxDigits:
+				return tok.reject(b[:p], fmt.Sprintf("number longer than %d digits", maxDigits)), p, nil
+			}
+			tok.args = append(tok.args, v)
+		}
+		if p >= len(b) || b[p] != ')' {
+			return tok.reject(b[:p], "expected ')'"), p, nil
+		}
+		tok.text = string(b[:p+1])
+		return tok, p + 1, ins
+	}
+	return token{}, 0, nil
+}
+
+// run reads the memory once from start to end, doing every instruction it
+// can find, and returns the total.
+func (c *computer) run(r io.Reader) (int, error) {
+	br := bufio.NewReader(r)
+	var offset int64
+	for {
+		b, err := br.Peek(c.longest)
+		if len(b) == 0 {
+			if err == io.EOF {
+				return c.total, nil
+			}
+			return c.total, err
+		}
+		tok, n, ins := c.lex(b, offset)
+		switch {
+		case n == 0:
+			n = 1
+		case ins == nil:
+			// something else might start inside the broken one
+			n = 1
+			c.tracef("@%d reject %v", tok.offset, tok)
+		case ins.handler(c, tok.args):
+			c.tracef("@%d accept %v", tok.offset, tok)
+		default:
+			c.tracef("@%d skip %v", tok.offset, tok)
+		}
+		br.Discard(n)
+		offset += int64(n)
+	}
+}
+
+func (c *computer) tracef(format string, args ...any) {
+	if c.trace != nil {
+		fmt.Fprintf(c.trace, format+"\n", args...)
+	}
+}
+
+func mul(c *computer, args []int) bool {
+	if c.enabled {
+		c.total += args[0] * args[1]
+	}
+	return c.enabled
+}
+
+func part1(r io.Reader, trace io.Writer) (int, error) {
+	c := newComputer()
+	c.trace = trace
+	c.register("mul", 2, mul)
+	return c.run(r)
+}
+
+func part2(r io.Reader, trace io.Writer) (int, error) {
+	c := newComputer()
+	c.trace = trace
+	c.register("mul", 2, mul)
+	c.register("do", 0, func(c *computer, args []int) bool {
+		c.enabled = true
+		return true
+	})
+	c.register("don't", 0, func(c *computer, args []int) bool {
+		c.enabled = false
+		return true
+	})
+	return c.run(r)
+}
+
+// onText runs a part over the input, tracing to stdout if the arguments say
+// "trace", as in "aoc run -v 3 trace".
+func onText(f func(r io.Reader, trace io.Writer) (int, error)) aoc.Part {
+	return func(in *aoc.Input) (any, error) {
+		var trace io.Writer
+		for _, arg := range in.Args {
+			if arg != "trace" {
+				return nil, fmt.Errorf("unknown argument %q", arg)
+			}
+			trace = os.Stdout
+		}
+		return f(strings.NewReader(in.Text), trace)
+	}
+}
+
+func init() {
+	aoc.Register(3, aoc.Parts{onText(part1), onText(part2)})
+}
@@ -0,0 +1,74 @@
+package day03
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kentquirk/aoc2024/aoc/aoctest"
+)
+
+func TestAnswers(t *testing.T) {
+	aoctest.Answers(t, 3)
+}
+
+func BenchmarkParts(b *testing.B) {
+	aoctest.Benchmark(b, 3)
+}
+
+func Test_computer(t *testing.T) {
+	tests := []struct {
+		name   string
+		memory string
+		want   int
+	}{
+		{"plain", "mul(2,4)mul(3,3)", 17},
+		{"too many digits", "mul(1234,5)mul(123,5)", 615},
+		{"spaces", "mul( 2,4)mul(2 ,4)mul(2,4 )", 0},
+		{"broken one first", "mul(mul(2,3)", 6},
+		{"cut off", "mul(2,4", 0},
+		{"switched off", "don't()mul(2,4)do()mul(1,1)", 1},
+		{"do without parens", "don'tmul(2,4)", 8},
+		{"sum", "sum(1,2,3)mul(2,2)", 10},
+		{"sum too short", "sum(1,2)", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newComputer()
+			c.register("mul", 2, mul)
+			c.register("do", 0, func(c *computer, args []int) bool {
+				c.enabled = true
+				return true
+			})
+			c.register("don't", 0, func(c *computer, args []int) bool {
+				c.enabled = false
+				return true
+			})
+			c.register("sum", 3, func(c *computer, args []int) bool {
+				c.total += args[0] + args[1] + args[2]
+				return true
+			})
+			got, err := c.run(strings.NewReader(tt.memory))
+			if err != nil || got != tt.want {
+				t.Errorf("run() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func Test_trace(t *testing.T) {
+	var trace strings.Builder
+	got, err := part2(strings.NewReader("xmul(2,4)don't()mul(1000,1)_mul(5,5)do()?mul(8,5)"), &trace)
+	if err != nil || got != 48 {
+		t.Errorf("part2() = %v, %v, want 48", got, err)
+	}
+	want := `@1 accept mul(2,4)
+@9 accept don't()
+@16 reject "mul(1000": number longer than 3 digits
+@28 skip mul(5,5)
+@36 accept do()
+@41 accept mul(8,5)
+`
+	if trace.String() != want {
+		t.Errorf("trace is\n%s\nwant\n%s", trace.String(), want)
+	}
+}
@@ -0,0 +1,173 @@
+package day04
+
+import (
+	"fmt"
+
+	"github.com/kentquirk/aoc2024/aoc"
+	"github.com/kentquirk/aoc2024/aoc/geom"
+	"github.com/kentquirk/aoc2024/aoc/grid"
+)
+
+// wildcard is the cell in a shape that matches any letter
+const wildcard = '.'
+
+// match is somewhere a word or a shape was found. For a word, dir is the way
+// it reads; a shape has no direction.
+type match struct {
+	at    grid.Point   // the first letter, or the top left of the shape
+	dir   geom.Dir8    // only for words
+	cells []grid.Point // every cell that's part of the match
+}
+
+// findWord finds every place word appears, reading in any of the 8
+// directions. A word that reads the same backwards is found twice, but a
+// one-letter word reads every way at once, so it's found just once with no
+// direction.
+func findWord(g *grid.Grid[byte], word string) []match {
+	if word == "" {
+		return nil
+	}
+	var found []match
+	for p, c := range g.All() {
+		if c != word[0] {
+			continue
+		}
+		if len(word) == 1 {
+			found = append(found, match{at: p, cells: []grid.Point{p}})
+			continue
+		}
+		for _, d := range geom.Dirs8 {
+			cells := []grid.Point{p}
+			q := p
+			for i := 1; i < len(word); i++ {
+				q = q.Move8(d)
+				if c, ok := g.Get(q); !ok || c != word[i] {
+					cells = nil
+					break
+				}
+				cells = append(cells, q)
+			}
+			if cells != nil {
+				found = append(found, match{at: p, dir: d, cells: cells})
+			}
+		}
+	}
+	return found
+}
+
+// parseShape reads a shape to look for, one row per line, where '.' matches
+// anything. For example, an X made of two MASes is
+//
+//	M.S
+//	.A.
+//	M.S
+func parseShape(lines ...string) (*grid.Grid[byte], error) {
+	return grid.Bytes(lines)
+}
+
+// rotations returns the shape turned each of the four ways, leaving out any
+// that look the same as one already there
+func rotations(shape *grid.Grid[byte]) []*grid.Grid[byte] {
+	var all []*grid.Grid[byte]
+	seen := make(map[string]bool)
+	for range 4 {
+		if s := shape.String(); !seen[s] {
+			seen[s] = true
+			all = append(all, shape)
+		}
+		shape = shape.RotateRight()
+	}
+	return all
+}
+
+// findShape finds every place the shape fits, turned any of the four ways
+func findShape(g *grid.Grid[byte], shape *grid.Grid[byte]) []match {
+	var found []match
+	for _, s := range rotations(shape) {
+		for top := range g.Height - s.Height + 1 {
+			for left := range g.Width - s.Width + 1 {
+				corner := grid.Point{X: left, Y: top}
+				var cells []grid.Point
+				fits := true
+				for offset, want := range s.All() {
+					if want == wildcard {
+						continue
+					}
+					p := corner.Add(offset)
+					if g.At(p) != want {
+						fits = false
+						break
+					}
+					cells = append(cells, p)
+				}
+				if fits {
+					found = append(found, match{at: corner, cells: cells})
+				}
+			}
+		}
+	}
+	return found
+}
+
+// highlight draws the grid with every letter that isn't part of a match
+// replaced by a '.', the way the puzzle does
+func highlight(g *grid.Grid[byte], matches []match) string {
+	used := make(map[grid.Point]bool)
+	for _, m := range matches {
+		for _, p := range m.cells {
+			used[p] = true
+		}
+	}
+	return g.Format(func(p grid.Point, c byte) string {
+		if !used[p] {
+			return "."
+		}
+		return string(c)
+	})
+}
+
+// search runs find on the input and returns the number of matches. With
+// "show" in the arguments, as in "aoc run -v 4 show", it also prints where
+// they are.
+func search(find func(g *grid.Grid[byte]) []match) aoc.Part {
+	return func(in *aoc.Input) (any, error) {
+		show := false
+		for _, arg := range in.Args {
+			if arg != "show" {
+				return nil, fmt.Errorf("unknown argument %q", arg)
+			}
+			show = true
+		}
+		g, err := grid.Bytes(in.Lines)
+		if err != nil {
+			return nil, err
+		}
+		found := find(g)
+		if show {
+			fmt.Print(highlight(g, found))
+		}
+		return len(found), nil
+	}
+}
+
+var xmas = []string{
+	"M.S",
+	".A.",
+	"M.S",
+}
+
+func part1(g *grid.Grid[byte]) []match {
+	return findWord(g, "XMAS")
+}
+
+func part2(g *grid.Grid[byte]) []match {
+	shape, err := parseShape(xmas...)
+	if err != nil {
+		panic(err)
+	}
+	return findShape(g, shape)
+}
+
+func init() {
+	aoc.Register(4, aoc.Parts{search(part1), search(part2)})
+}
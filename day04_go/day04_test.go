@@ -0,0 +1,109 @@
+package day04
+
+import (
+	"testing"
+
+	"github.com/kentquirk/aoc2024/aoc/aoctest"
+	"github.com/kentquirk/aoc2024/aoc/geom"
+	"github.com/kentquirk/aoc2024/aoc/grid"
+)
+
+func TestAnswers(t *testing.T) {
+	aoctest.Answers(t, 4)
+}
+
+func BenchmarkParts(b *testing.B) {
+	aoctest.Benchmark(b, 4)
+}
+
+var puzzle = []string{
+	"..X...",
+	".SAMX.",
+	".A..A.",
+	"XMAS.S",
+	".X....",
+}
+
+func Test_findWord(t *testing.T) {
+	g, _ := grid.Bytes(puzzle)
+	tests := []struct {
+		word string
+		want []match
+	}{
+		{"XMAS", []match{
+			{at: grid.Point{X: 2, Y: 0}, dir: geom.SE},
+			{at: grid.Point{X: 4, Y: 1}, dir: geom.W},
+			{at: grid.Point{X: 0, Y: 3}, dir: geom.E},
+			{at: grid.Point{X: 1, Y: 4}, dir: geom.N},
+		}},
+		{"SAMX", []match{
+			{at: grid.Point{X: 1, Y: 1}, dir: geom.E},
+			{at: grid.Point{X: 1, Y: 1}, dir: geom.S},
+			{at: grid.Point{X: 3, Y: 3}, dir: geom.W},
+			{at: grid.Point{X: 5, Y: 3}, dir: geom.NW},
+		}},
+		// one letter reads every way, but it's only one match
+		{"X", []match{
+			{at: grid.Point{X: 2, Y: 0}},
+			{at: grid.Point{X: 4, Y: 1}},
+			{at: grid.Point{X: 0, Y: 3}},
+			{at: grid.Point{X: 1, Y: 4}},
+		}},
+		{"ZZ", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			got := findWord(g, tt.word)
+			if len(got) != len(tt.want) {
+				t.Fatalf("findWord() found %d, want %d: %v", len(got), len(tt.want), got)
+			}
+			for i := range got {
+				if got[i].at != tt.want[i].at || got[i].dir != tt.want[i].dir || len(got[i].cells) != len(tt.word) {
+					t.Errorf("findWord()[%d] = %v %v, want %v %v", i, got[i].at, got[i].dir, tt.want[i].at, tt.want[i].dir)
+				}
+			}
+		})
+	}
+}
+
+func Test_findShape(t *testing.T) {
+	g, _ := grid.Bytes([]string{
+		"M.SMM",
+		".A.A.",
+		"M.SSM",
+	})
+	tests := []struct {
+		name  string
+		shape []string
+		want  int
+	}{
+		{"x-mas", xmas, 2},
+		{"corner", []string{"SS", "A."}, 1},
+		{"all wild", []string{"..", ".."}, 8},
+		{"too big", []string{"......"}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shape, err := parseShape(tt.shape...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := findShape(g, shape); len(got) != tt.want {
+				t.Errorf("findShape() found %d, want %d: %v", len(got), tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_highlight(t *testing.T) {
+	g, _ := grid.Bytes(puzzle)
+	want := `..X...
+...M..
+....A.
+.....S
+......
+`
+	if got := highlight(g, findWord(g, "XMAS")[:1]); got != want {
+		t.Errorf("highlight() =\n%s\nwant\n%s", got, want)
+	}
+}
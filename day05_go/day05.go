@@ -0,0 +1,148 @@
+package day05
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kentquirk/aoc2024/aoc"
+)
+
+// rule says page before has to be printed at some point before page after,
+// if both are in the update
+type rule struct {
+	before, after int
+}
+
+func (r rule) String() string {
+	return fmt.Sprintf("%d|%d", r.before, r.after)
+}
+
+// ordering is the set of rules. The rules as a whole don't have to describe
+// a consistent order; only the ones that apply to a particular update do.
+type ordering map[rule]bool
+
+// update is one line of pages to print, and where it came from
+type update struct {
+	line  int
+	pages []int
+}
+
+func (u update) middle() int {
+	return u.pages[len(u.pages)/2]
+}
+
+func parse(lines []string) (ordering, []update, error) {
+	rules := make(ordering)
+	var updates []update
+	for i, line := range lines {
+		switch {
+		case strings.Contains(line, "|"):
+			pages := aoc.Ints(line)
+			if len(pages) != 2 {
+				return nil, nil, fmt.Errorf("line %d: a rule needs two pages: %q", i+1, line)
+			}
+			rules[rule{pages[0], pages[1]}] = true
+		case strings.TrimSpace(line) != "":
+			pages := aoc.Ints(line)
+			if len(pages) == 0 {
+				return nil, nil, fmt.Errorf("line %d: no pages in %q", i+1, line)
+			}
+			updates = append(updates, update{i + 1, pages})
+		}
+	}
+	return rules, updates, nil
+}
+
+// compare orders two pages by the rules: -1 if a has to come first, 1 if b
+// does, and 0 if there's no rule about them
+func (o ordering) compare(a, b int) int {
+	switch {
+	case o[rule{a, b}]:
+		return -1
+	case o[rule{b, a}]:
+		return 1
+	}
+	return 0
+}
+
+// violations returns every rule the pages break, checking each pair of
+// pages once
+func (o ordering) violations(pages []int) []rule {
+	var broken []rule
+	for i, a := range pages {
+		for _, b := range pages[i+1:] {
+			if o[rule{b, a}] {
+				broken = append(broken, rule{b, a})
+			}
+		}
+	}
+	return broken
+}
+
+// repair puts the pages in order. Sorting with compare is enough when the
+// rules say how every pair of pages goes, as they do in the puzzle; if they
+// leave gaps it falls back to placing pages one at a time. If the rules that
+// apply go round in a circle there is no right order, and it returns an
+// error naming them.
+func (o ordering) repair(pages []int) ([]int, error) {
+	fixed := slices.Clone(pages)
+	slices.SortStableFunc(fixed, o.compare)
+	if len(o.violations(fixed)) == 0 {
+		return fixed, nil
+	}
+
+	fixed = fixed[:0]
+	left := slices.Clone(pages)
+	for len(left) > 0 {
+		// find a page that nothing left has to come before
+		next := slices.IndexFunc(left, func(a int) bool {
+			return !slices.ContainsFunc(left, func(b int) bool { return o[rule{b, a}] })
+		})
+		if next < 0 {
+			return nil, fmt.Errorf("the rules for pages %v go round in a circle: %v", left, o.violations(left))
+		}
+		fixed = append(fixed, left[next])
+		left = slices.Delete(left, next, next+1)
+	}
+	return fixed, nil
+}
+
+func part1(lines []string) (int, error) {
+	rules, updates, err := parse(lines)
+	if err != nil {
+		return 0, err
+	}
+	total := 0
+	for _, u := range updates {
+		if broken := rules.violations(u.pages); len(broken) > 0 {
+			fmt.Printf("line %d: breaks %v\n", u.line, broken)
+			continue
+		}
+		total += u.middle()
+	}
+	return total, nil
+}
+
+func part2(lines []string) (int, error) {
+	rules, updates, err := parse(lines)
+	if err != nil {
+		return 0, err
+	}
+	total := 0
+	for _, u := range updates {
+		if len(rules.violations(u.pages)) == 0 {
+			continue
+		}
+		fixed, err := rules.repair(u.pages)
+		if err != nil {
+			return 0, fmt.Errorf("line %d: %w", u.line, err)
+		}
+		total += update{u.line, fixed}.middle()
+	}
+	return total, nil
+}
+
+func init() {
+	aoc.Register(5, aoc.Parts{aoc.OnLinesErr(part1), aoc.OnLinesErr(part2)})
+}
@@ -0,0 +1,73 @@
+package day05
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kentquirk/aoc2024/aoc/aoctest"
+)
+
+func TestAnswers(t *testing.T) {
+	aoctest.Answers(t, 5)
+}
+
+func BenchmarkParts(b *testing.B) {
+	aoctest.Benchmark(b, 5)
+}
+
+var rules = ordering{
+	{47, 53}: true, {97, 13}: true, {97, 61}: true, {75, 29}: true,
+	{61, 13}: true, {75, 53}: true, {29, 13}: true, {97, 29}: true,
+	{53, 29}: true, {61, 53}: true, {97, 53}: true, {61, 29}: true,
+	{47, 13}: true, {75, 47}: true, {97, 75}: true, {47, 61}: true,
+	{75, 61}: true, {47, 29}: true, {75, 13}: true, {53, 13}: true,
+	// these two go round in a circle, which only matters if an update has
+	// both pages
+	{1, 2}: true, {2, 1}: true,
+}
+
+func Test_ordering_violations(t *testing.T) {
+	tests := []struct {
+		name  string
+		pages []int
+		want  []rule
+	}{
+		{"in order", []int{75, 47, 61, 53, 29}, nil},
+		{"one pair", []int{75, 97, 47, 61, 53}, []rule{{97, 75}}},
+		{"several", []int{97, 13, 75, 29, 47}, []rule{{75, 13}, {29, 13}, {47, 13}, {47, 29}}},
+		{"no rules", []int{5, 4, 3}, nil},
+		{"circle", []int{1, 2}, []rule{{2, 1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rules.violations(tt.pages); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("violations() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ordering_repair(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   ordering
+		pages   []int
+		want    []int
+		wantErr bool
+	}{
+		{"one pair", rules, []int{75, 97, 47, 61, 53}, []int{97, 75, 47, 61, 53}, false},
+		{"several", rules, []int{97, 13, 75, 29, 47}, []int{97, 75, 47, 29, 13}, false},
+		{"circle elsewhere", rules, []int{61, 75, 1}, []int{75, 61, 1}, false},
+		{"circle", rules, []int{2, 75, 1}, nil, true},
+		// nothing says how 3 and 1 go, so sorting alone can't do it
+		{"gaps", ordering{{1, 2}: true, {2, 3}: true}, []int{3, 2, 1}, []int{1, 2, 3}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.rules.repair(tt.pages)
+			if (err != nil) != tt.wantErr || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("repair() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
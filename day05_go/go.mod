@@ -2,4 +2,6 @@ module github.com/kentquirk/aoc2024/day05
 
 go 1.23
 
-require github.com/hmdsefi/gograph v0.4.2
+require github.com/kentquirk/aoc2024 v0.0.0
+
+replace github.com/kentquirk/aoc2024 => ../
@@ -0,0 +1,388 @@
+package day06
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"slices"
+	"sync"
+
+	"github.com/kentquirk/aoc2024/aoc"
+	"github.com/kentquirk/aoc2024/aoc/geom"
+	"github.com/kentquirk/aoc2024/aoc/grid"
+)
+
+// floor is the lab as a dense grid, with jump tables so a guard can be moved
+// straight to the next obstacle instead of one step at a time.
+type floor struct {
+	blocked *grid.Grid[bool]
+	// stop[d] holds, for each cell, where a guard there heading in direction
+	// d stops: the cell in front of the next obstacle, or the first cell off
+	// the map if there isn't one
+	stop [4]*grid.Grid[grid.Point]
+	// undo is the jump table entries block changed, so unblock can put
+	// them back
+	undo []jump
+}
+
+// jump is one jump table entry
+type jump struct {
+	d    geom.Dir4
+	p    grid.Point
+	stop grid.Point
+}
+
+// patrol is where a guard is and which way they're facing
+type patrol struct {
+	p grid.Point
+	d geom.Dir4
+}
+
+func parseFloor(lines []string) (*floor, patrol, error) {
+	var start patrol
+	found := false
+	blocked, err := grid.Parse(lines, func(p grid.Point, b byte) bool {
+		if d, ok := geom.ParseDir4(b); ok && d.Glyph() == b {
+			start, found = patrol{p, d}, true
+		}
+		return b == '#'
+	})
+	if err != nil {
+		return nil, start, err
+	}
+	if !found {
+		return nil, start, errors.New("there's no guard")
+	}
+	f := &floor{blocked: blocked}
+	f.buildStops()
+	return f, start, nil
+}
+
+// buildStops fills in the jump tables. Each cell's stop comes from the cell
+// in front of it, so the cells are visited starting from the far side.
+func (f *floor) buildStops() {
+	w, h := f.blocked.Width, f.blocked.Height
+	for _, d := range geom.Dirs4 {
+		stop := grid.New[grid.Point](w, h)
+		delta := d.Delta()
+		for i := range w * h {
+			// Up and Left look at cells already seen in reading order; Down and
+			// Right at cells still to come, so go backwards for those
+			if delta.X > 0 || delta.Y > 0 {
+				i = w*h - 1 - i
+			}
+			p := grid.Point{X: i % w, Y: i / w}
+			q := p.Move(d)
+			switch blocked, ok := f.blocked.Get(q); {
+			case !ok:
+				stop.Set(p, q)
+			case blocked:
+				stop.Set(p, p)
+			default:
+				stop.Set(p, stop.At(q))
+			}
+		}
+		f.stop[d] = stop
+	}
+}
+
+// clone copies the floor, so the copy can have obstacles added without
+// changing the original
+func (f *floor) clone() *floor {
+	c := &floor{blocked: f.blocked.Clone()}
+	for d, stop := range f.stop {
+		c.stop[d] = stop.Clone()
+	}
+	return c
+}
+
+// block adds an obstacle at b. Only the jump tables for the cells in b's
+// row and column change: heading towards b from anywhere between it and
+// the obstacle before it, the guard now stops in front of b.
+func (f *floor) block(b grid.Point) {
+	f.blocked.Set(b, true)
+	f.undo = f.undo[:0]
+	for _, d := range geom.Dirs4 {
+		back := d.Opposite()
+		front := b.Move(back)
+		// that includes the obstacle before it, whose entry is never used
+		// but is kept the same as if the tables had been built with b there
+		for p := front; f.blocked.In(p); p = p.Move(back) {
+			f.undo = append(f.undo, jump{d, p, f.stop[d].At(p)})
+			f.stop[d].Set(p, front)
+			if f.blocked.At(p) {
+				break
+			}
+		}
+	}
+}
+
+// unblock takes away the obstacle block added at b
+func (f *floor) unblock(b grid.Point) {
+	for _, j := range f.undo {
+		f.stop[j.d].Set(j.p, j.stop)
+	}
+	f.undo = f.undo[:0]
+	f.blocked.Set(b, false)
+}
+
+// loops reports whether a guard starting at g with an obstacle added at
+// blocker goes round forever. seen is scratch space, one entry per cell and
+// direction; entries equal to run have been seen on this go. The floor is
+// changed while it looks, so each goroutine needs a clone of its own.
+func (f *floor) loops(g patrol, blocker grid.Point, seen []int32, run int32) bool {
+	f.block(blocker)
+	defer f.unblock(blocker)
+	for {
+		s := f.stop[g.d].At(g.p)
+		if !f.blocked.In(s) {
+			return false
+		}
+		i := (s.Y*f.blocked.Width+s.X)*4 + int(g.d)
+		if seen[i] == run {
+			return true
+		}
+		seen[i] = run
+		g = patrol{s, g.d.TurnRight()}
+	}
+}
+
+// candidate is a place to put an obstacle, and where the guard is just
+// before walking into it for the first time. Nothing changes before then, so
+// that's where checking for a loop can start.
+type candidate struct {
+	blocker grid.Point
+	from    patrol
+}
+
+// blockerCandidates walks the guard's patrol one step at a time and returns
+// each cell they walk into, except the one they start on.
+func (f *floor) blockerCandidates(start patrol) ([]candidate, error) {
+	visited := grid.New[bool](f.blocked.Width, f.blocked.Height)
+	visited.Set(start.p, true)
+	var candidates []candidate
+	g := start
+	for steps := 0; ; steps++ {
+		if steps > 4*f.blocked.Width*f.blocked.Height {
+			return nil, errors.New("the guard never leaves")
+		}
+		q := g.p.Move(g.d)
+		blocked, ok := f.blocked.Get(q)
+		switch {
+		case !ok:
+			return candidates, nil
+		case blocked:
+			g.d = g.d.TurnRight()
+			continue
+		case !visited.At(q):
+			visited.Set(q, true)
+			candidates = append(candidates, candidate{q, g})
+		}
+		g.p = q
+	}
+}
+
+// countLoops counts the candidates that trap the guard in a loop, checking
+// them in parallel, each worker on its own copy of the floor.
+func (f *floor) countLoops(candidates []candidate) int {
+	workers := runtime.GOMAXPROCS(0)
+	counts := make([]int, workers)
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			f := f.clone()
+			seen := make([]int32, f.blocked.Width*f.blocked.Height*4)
+			for i := w; i < len(candidates); i += workers {
+				c := candidates[i]
+				if f.loops(c.from, c.blocker, seen, int32(i+1)) {
+					counts[w]++
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	total := 0
+	for _, n := range counts {
+		total += n
+	}
+	return total
+}
+
+// guardState is what happened on the guard's last step.
+type guardState int
+
+const (
+	moving  guardState = iota
+	turning            // hit an obstacle and turned right
+	offmap             // walked off the edge
+	looping            // back where they've been before, facing the same way
+	boxedIn            // obstacles on all four sides
+)
+
+func (s guardState) String() string {
+	return [...]string{"moving", "turning", "off the map", "looping", "boxed in"}[s]
+}
+
+// lab walks the guard around the floor one step at a time, remembering
+// which ways they've gone through each cell.
+type lab struct {
+	*floor
+	g       patrol
+	turns   int               // turns in a row without moving
+	visited *grid.Grid[uint8] // a bit for each direction the guard has faced
+}
+
+func parseLab(lines []string) (*lab, error) {
+	f, start, err := parseFloor(lines)
+	if err != nil {
+		return nil, err
+	}
+	l := &lab{floor: f, g: start, visited: grid.New[uint8](f.blocked.Width, f.blocked.Height)}
+	l.record()
+	return l, nil
+}
+
+// record notes the guard's position and direction. It returns false if
+// they've already been here facing this way, which means they're in a loop.
+func (l *lab) record() bool {
+	bit := uint8(1) << l.g.d
+	seen := l.visited.At(l.g.p)
+	l.visited.Set(l.g.p, seen|bit)
+	return seen&bit == 0
+}
+
+// step moves the guard forward one cell or, if there's an obstacle in the
+// way, turns them right, and says what happened.
+func (l *lab) step() guardState {
+	q := l.g.p.Move(l.g.d)
+	blocked, ok := l.blocked.Get(q)
+	switch {
+	case !ok:
+		l.g.p = q
+		return offmap
+	case blocked:
+		l.turns++
+		if l.turns == 4 {
+			return boxedIn
+		}
+		l.g.d = l.g.d.TurnRight()
+		if !l.record() {
+			return looping
+		}
+		return turning
+	}
+	l.turns = 0
+	l.g.p = q
+	if !l.record() {
+		return looping
+	}
+	return moving
+}
+
+// walk steps the guard until they leave, loop, or get stuck, calling frame
+// (if it isn't nil) after every step.
+func (l *lab) walk(frame func(l *lab)) guardState {
+	for {
+		state := l.step()
+		if frame != nil {
+			frame(l)
+		}
+		if state != moving && state != turning {
+			return state
+		}
+	}
+}
+
+func (l *lab) numPositions() int {
+	n := 0
+	for _, dirs := range l.visited.All() {
+		if dirs != 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// frame draws the lab with the guard, the obstacles, and the path so far:
+// '|' where the guard has gone up or down, '-' for left or right, and '+'
+// for both.
+func (l *lab) frame() string {
+	const upDown = 1<<geom.Up | 1<<geom.Down
+	const leftRight = 1<<geom.Left | 1<<geom.Right
+	return l.blocked.Format(func(p grid.Point, blocked bool) string {
+		dirs := l.visited.At(p)
+		switch {
+		case p == l.g.p:
+			return string(l.g.d.Glyph())
+		case blocked:
+			return "#"
+		case dirs&upDown != 0 && dirs&leftRight != 0:
+			return "+"
+		case dirs&upDown != 0:
+			return "|"
+		case dirs&leftRight != 0:
+			return "-"
+		}
+		return "."
+	})
+}
+
+// exportFrames walks the guard, writing the lab to w before the first step
+// and after each one, with a blank line between frames, so the patrol can be
+// played back. It returns how the walk ended.
+func (l *lab) exportFrames(w io.Writer) (guardState, error) {
+	var err error
+	write := func(l *lab) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, "%s\n", l.frame())
+		}
+	}
+	write(l)
+	state := l.walk(write)
+	return state, err
+}
+
+func part1(lines []string, args []string) (int, error) {
+	l, err := parseLab(lines)
+	if err != nil {
+		return 0, err
+	}
+	var state guardState
+	if slices.Contains(args, "frames") {
+		state, err = l.exportFrames(os.Stdout)
+		if err != nil {
+			return 0, err
+		}
+	} else {
+		state = l.walk(nil)
+		fmt.Print(l.frame())
+	}
+	if state != offmap {
+		return 0, fmt.Errorf("the guard never leaves: %v", state)
+	}
+	return l.numPositions(), nil
+}
+
+func part2(lines []string) (int, error) {
+	f, start, err := parseFloor(lines)
+	if err != nil {
+		return 0, err
+	}
+	candidates, err := f.blockerCandidates(start)
+	if err != nil {
+		return 0, err
+	}
+	return f.countLoops(candidates), nil
+}
+
+func init() {
+	aoc.Register(6, aoc.Parts{
+		// "aoc run -v 6 frames" shows each step of the patrol
+		func(in *aoc.Input) (any, error) { return part1(in.Lines, in.Args) },
+		aoc.OnLinesErr(part2),
+	})
+}
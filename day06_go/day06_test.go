@@ -0,0 +1,170 @@
+package day06
+
+import (
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/kentquirk/aoc2024/aoc/aoctest"
+	"github.com/kentquirk/aoc2024/aoc/grid"
+)
+
+func TestAnswers(t *testing.T) {
+	aoctest.Answers(t, 6)
+}
+
+func BenchmarkParts(b *testing.B) {
+	aoctest.Benchmark(b, 6)
+}
+
+// slowLoops walks the guard one step at a time with an obstacle at blocker,
+// to check the jump tables against
+func slowLoops(f *floor, g patrol, blocker grid.Point) bool {
+	seen := make(map[patrol]bool)
+	for !seen[g] {
+		seen[g] = true
+		q := g.p.Move(g.d)
+		blocked, ok := f.blocked.Get(q)
+		switch {
+		case !ok:
+			return false
+		case blocked || q == blocker:
+			g.d = g.d.TurnRight()
+		default:
+			g.p = q
+		}
+	}
+	return true
+}
+
+func Test_floor_loops(t *testing.T) {
+	rnd := rand.New(rand.NewPCG(6, 6))
+	for n := range 200 {
+		w, h := 3+rnd.IntN(8), 3+rnd.IntN(8)
+		lines := make([]string, h)
+		for y := range lines {
+			row := make([]byte, w)
+			for x := range row {
+				row[x] = '.'
+				if rnd.IntN(5) == 0 {
+					row[x] = '#'
+				}
+			}
+			lines[y] = string(row)
+		}
+		start := grid.Point{X: rnd.IntN(w), Y: rnd.IntN(h)}
+		lines[start.Y] = lines[start.Y][:start.X] + "^" + lines[start.Y][start.X+1:]
+
+		f, g, err := parseFloor(lines)
+		if err != nil {
+			t.Fatal(err)
+		}
+		seen := make([]int32, w*h*4)
+		for p, blocked := range f.blocked.All() {
+			if blocked || p == start {
+				continue
+			}
+			if got, want := f.loops(g, p, seen, int32(p.Y*w+p.X+1)), slowLoops(f, g, p); got != want {
+				t.Fatalf("map %d with a blocker at %v: loops() = %v, want %v\n%s", n, p, got, want, strings.Join(lines, "\n"))
+			}
+		}
+	}
+}
+
+// sameStops reports whether two floors have the same jump tables
+func sameStops(a, b *floor) bool {
+	for d := range a.stop {
+		if a.stop[d].String() != b.stop[d].String() {
+			return false
+		}
+	}
+	return true
+}
+
+func Test_floor_block(t *testing.T) {
+	lines := []string{
+		"....#.....",
+		".........#",
+		"..........",
+		"..#.......",
+		".......#..",
+		"..........",
+		".#..^.....",
+		"........#.",
+		"#.........",
+		"......#...",
+	}
+	f, _, err := parseFloor(lines)
+	if err != nil {
+		t.Fatal(err)
+	}
+	original := f.clone()
+	for p, blocked := range original.blocked.All() {
+		if blocked {
+			continue
+		}
+		// the tables after adding the obstacle should be the same as if
+		// they'd been built with it there
+		want := original.clone()
+		want.blocked.Set(p, true)
+		want.buildStops()
+		f.block(p)
+		if !sameStops(f, want) {
+			t.Fatalf("block(%v) left the wrong jump tables", p)
+		}
+		f.unblock(p)
+		if !sameStops(f, original) || f.blocked.At(p) {
+			t.Fatalf("unblock(%v) didn't put the floor back", p)
+		}
+	}
+}
+
+func Test_lab_walk(t *testing.T) {
+	tests := []struct {
+		name      string
+		lines     []string
+		want      guardState
+		wantCells int
+	}{
+		{"leaves", []string{"...", ".^.", "..."}, offmap, 2},
+		{"boxed in", []string{".#.", "#^#", ".#."}, boxedIn, 1},
+		{"dead end", []string{".#.", "#.#", "...", ".^."}, offmap, 3},
+		{"loop", []string{".#..", "...#", "#^..", "..#."}, looping, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, err := parseLab(tt.lines)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := l.walk(nil); got != tt.want || l.numPositions() != tt.wantCells {
+				t.Errorf("walk() = %v with %d cells, want %v with %d\n%s", got, l.numPositions(), tt.want, tt.wantCells, l.frame())
+			}
+		})
+	}
+}
+
+func Test_lab_exportFrames(t *testing.T) {
+	l, err := parseLab([]string{
+		".#..",
+		"...#",
+		".^..",
+		"....",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sb strings.Builder
+	if state, err := l.exportFrames(&sb); err != nil || state != offmap {
+		t.Fatalf("exportFrames() = %v, %v", state, err)
+	}
+	frames := strings.Split(strings.TrimSuffix(sb.String(), "\n\n"), "\n\n")
+	// the start, then up, turn, right, turn, down, down, and off
+	if len(frames) != 8 {
+		t.Fatalf("got %d frames, want 8:\n%s", len(frames), sb.String())
+	}
+	want := ".#..\n.+v#\n.|..\n....\n"
+	if got := frames[4] + "\n"; got != want {
+		t.Errorf("frame 4 is\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,337 @@
+package day07
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/kentquirk/aoc2024/aoc"
+)
+
+var (
+	// errNoInverse means there's no value that makes the operator give the
+	// target, or none that fits in an int
+	errNoInverse = errors.New("no inverse")
+	// errAnyValue means the operator gives the target whatever the value on
+	// its left is, like anything times 0
+	errAnyValue  = errors.New("any value")
+	errOverflow  = errors.New("overflow")
+	errUndefined = errors.New("undefined")
+)
+
+// operator is something that can go between two numbers in an equation.
+// apply works out a op b; undo works backwards from a op b = target to find
+// every a that works, since there can be more than one, like 3 and -3 for
+// a ** 2 = 9. Both fail rather than overflow.
+type operator struct {
+	symbol string
+	apply  func(a, b int) (int, error)
+	undo   func(target, b int) ([]int, error)
+}
+
+// operators holds every operator there is, by symbol
+var operators = make(map[string]operator)
+
+func register(op operator) {
+	operators[op.symbol] = op
+}
+
+// lookup finds the operators with the given symbols
+func lookup(symbols ...string) ([]operator, error) {
+	ops := make([]operator, len(symbols))
+	for i, s := range symbols {
+		op, ok := operators[s]
+		if !ok {
+			return nil, fmt.Errorf("unknown operator %q", s)
+		}
+		ops[i] = op
+	}
+	return ops, nil
+}
+
+func add(a, b int) (int, error) {
+	sum := a + b
+	if (sum > a) != (b > 0) {
+		return 0, errOverflow
+	}
+	return sum, nil
+}
+
+func sub(a, b int) (int, error) {
+	diff := a - b
+	if (diff < a) != (b > 0) {
+		return 0, errOverflow
+	}
+	return diff, nil
+}
+
+func mul(a, b int) (int, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+	p := a * b
+	if p/b != a || (a == -1 && b == math.MinInt) || (b == -1 && a == math.MinInt) {
+		return 0, errOverflow
+	}
+	return p, nil
+}
+
+// shift returns the power of 10 with as many zeros as b has digits, which
+// is what a has to be multiplied by to concatenate b onto it
+func shift(b int) (int, error) {
+	n := 10
+	for ; n <= b; n *= 10 {
+		if n > math.MaxInt/10 {
+			return 0, errOverflow
+		}
+	}
+	return n, nil
+}
+
+// undoWith turns a forward result into the result for undo
+func undoWith(a int, err error) ([]int, error) {
+	if err != nil {
+		return nil, errNoInverse
+	}
+	return []int{a}, nil
+}
+
+func init() {
+	register(operator{"+", add, func(t, b int) ([]int, error) {
+		return undoWith(sub(t, b))
+	}})
+	register(operator{"-", sub, func(t, b int) ([]int, error) {
+		return undoWith(add(t, b))
+	}})
+	register(operator{"*", mul, func(t, b int) ([]int, error) {
+		switch {
+		case b == 0 && t == 0:
+			return nil, errAnyValue
+		case b == 0 || t%b != 0:
+			return nil, errNoInverse
+		}
+		// MinInt / -1 wraps round to MinInt, so check it multiplies back
+		a := t / b
+		if p, err := mul(a, b); err != nil || p != t {
+			return nil, errNoInverse
+		}
+		return []int{a}, nil
+	}})
+	// concatenation, so 12 || 345 is 12345
+	register(operator{"||", func(a, b int) (int, error) {
+		if a < 0 || b < 0 {
+			return 0, errUndefined
+		}
+		n, err := shift(b)
+		if err != nil {
+			return 0, err
+		}
+		if a, err = mul(a, n); err != nil {
+			return 0, err
+		}
+		return add(a, b)
+	}, func(t, b int) ([]int, error) {
+		if t < 0 || b < 0 {
+			return nil, errNoInverse
+		}
+		n, err := shift(b)
+		if err != nil || t%n != b {
+			return nil, errNoInverse
+		}
+		return []int{t / n}, nil
+	}})
+	register(operator{"**", power, func(t, b int) ([]int, error) {
+		switch {
+		case b == 0 && t == 1:
+			return nil, errAnyValue
+		case b <= 0:
+			return nil, errNoInverse
+		}
+		return roots(t, b)
+	}})
+}
+
+func power(a, b int) (int, error) {
+	switch {
+	case b < 0:
+		return 0, errUndefined
+	case b == 0:
+		return 1, nil
+	case a == 0 || a == 1:
+		return a, nil
+	case a == -1 && b%2 == 0:
+		return 1, nil
+	case a == -1:
+		return -1, nil
+	}
+	p := 1
+	for range b {
+		var err error
+		if p, err = mul(p, a); err != nil {
+			return 0, err
+		}
+	}
+	return p, nil
+}
+
+// roots finds the whole numbers a where a ** b == t, for b > 0. For an
+// even b that's a positive root and its negative, if there are any.
+func roots(t, b int) ([]int, error) {
+	if b == 1 {
+		return []int{t}, nil
+	}
+	// a ** b only goes up as a does, from 0 for an even b or from the most
+	// negative a for an odd one, and no root is bigger than t, so there's
+	// a binary search for it
+	lo, hi := 0, max(t, 1)
+	switch {
+	case b%2 == 1:
+		lo = min(t, -1)
+	case t < 0:
+		return nil, errNoInverse
+	}
+	for lo <= hi {
+		// the average, rounded down, without overflowing
+		mid := (lo & hi) + (lo^hi)>>1
+		p, err := power(mid, b)
+		switch {
+		case err == nil && p == t && b%2 == 0 && mid > 0:
+			return []int{mid, -mid}, nil
+		case err == nil && p == t:
+			return []int{mid}, nil
+		case err != nil && mid > 0, err == nil && p > t:
+			hi = mid - 1
+		default:
+			lo = mid + 1
+		}
+	}
+	return nil, errNoInverse
+}
+
+// equation is a test value and the numbers that are supposed to make it
+type equation struct {
+	target int
+	values []int
+}
+
+func parse(lines []string) ([]equation, error) {
+	rows, err := aoc.Extractor{Strict: true}.IntsInLines(lines)
+	if err != nil {
+		return nil, err
+	}
+	var eqs []equation
+	for i, row := range rows {
+		switch len(row) {
+		case 0:
+			continue
+		case 1:
+			return nil, fmt.Errorf("line %d: no numbers after the test value", i+1)
+		}
+		eqs = append(eqs, equation{row[0], row[1:]})
+	}
+	return eqs, nil
+}
+
+// solve finds operators to go between the values, which are evaluated left
+// to right, to make the target. It works backwards from the target, undoing
+// the last operator, so a choice that can't work is dropped as soon as its
+// inverse doesn't exist instead of after trying everything that follows.
+func solve(target int, values []int, ops []operator) ([]operator, bool) {
+	n := len(values)
+	if n == 1 {
+		return nil, target == values[0]
+	}
+	for _, op := range ops {
+		as, err := op.undo(target, values[n-1])
+		var chosen []operator
+		ok := false
+		switch err {
+		case nil:
+			for _, a := range as {
+				if chosen, ok = solve(a, values[:n-1], ops); ok {
+					break
+				}
+			}
+		case errAnyValue:
+			chosen, ok = anything(values[:n-1], ops)
+		}
+		if ok {
+			return append(chosen, op), true
+		}
+	}
+	return nil, false
+}
+
+// anything finds operators that make the values come out to any value at
+// all without overflowing
+func anything(values []int, ops []operator) ([]operator, bool) {
+	var try func(acc int, rest []int) ([]operator, bool)
+	try = func(acc int, rest []int) ([]operator, bool) {
+		if len(rest) == 0 {
+			return nil, true
+		}
+		for _, op := range ops {
+			v, err := op.apply(acc, rest[0])
+			if err != nil {
+				continue
+			}
+			if chosen, ok := try(v, rest[1:]); ok {
+				return append([]operator{op}, chosen...), true
+			}
+		}
+		return nil, false
+	}
+	return try(values[0], values[1:])
+}
+
+// expression writes out the equation with the operators filled in, like
+// "190 = 10 * 19"
+func (e equation) expression(ops []operator) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d = %d", e.target, e.values[0])
+	for i, op := range ops {
+		fmt.Fprintf(&sb, " %s %d", op.symbol, e.values[i+1])
+	}
+	return sb.String()
+}
+
+// calibrate adds up the test values of the equations that can be made with
+// the operators, printing how each one is made
+func calibrate(lines []string, symbols ...string) (int, error) {
+	ops, err := lookup(symbols...)
+	if err != nil {
+		return 0, err
+	}
+	eqs, err := parse(lines)
+	if err != nil {
+		return 0, err
+	}
+	total := 0
+	for _, e := range eqs {
+		chosen, ok := solve(e.target, e.values, ops)
+		if !ok {
+			continue
+		}
+		fmt.Println(e.expression(chosen))
+		if total, err = add(total, e.target); err != nil {
+			return 0, fmt.Errorf("the total is too big for an int")
+		}
+	}
+	return total, nil
+}
+
+// withOperators runs a part with the operators from the arguments if there
+// are any, as in "aoc run 7 + - '*'"
+func withOperators(symbols ...string) aoc.Part {
+	return func(in *aoc.Input) (any, error) {
+		if len(in.Args) > 0 {
+			return calibrate(in.Lines, in.Args...)
+		}
+		return calibrate(in.Lines, symbols...)
+	}
+}
+
+func init() {
+	aoc.Register(7, aoc.Parts{withOperators("+", "*"), withOperators("+", "*", "||")})
+}
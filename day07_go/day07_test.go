@@ -0,0 +1,74 @@
+package day07
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kentquirk/aoc2024/aoc/aoctest"
+)
+
+func TestAnswers(t *testing.T) {
+	aoctest.Answers(t, 7)
+}
+
+func BenchmarkParts(b *testing.B) {
+	aoctest.Benchmark(b, 7)
+}
+
+func Test_solve(t *testing.T) {
+	tests := []struct {
+		name    string
+		symbols []string
+		target  int
+		values  []int
+		want    string
+	}{
+		{"multiply", []string{"+", "*"}, 190, []int{10, 19}, "190 = 10 * 19"},
+		{"mixed", []string{"+", "*"}, 292, []int{11, 6, 16, 20}, "292 = 11 + 6 * 16 + 20"},
+		{"no way", []string{"+", "*"}, 156, []int{15, 6}, ""},
+		{"concatenate", []string{"+", "*", "||"}, 156, []int{15, 6}, "156 = 15 || 6"},
+		{"concatenate zero", []string{"||"}, 100, []int{10, 0}, "100 = 10 || 0"},
+		{"concatenate middle", []string{"+", "*", "||"}, 7290, []int{6, 8, 6, 15}, "7290 = 6 * 8 || 6 * 15"},
+		{"subtract", []string{"+", "-"}, 3, []int{10, 4, 3}, "3 = 10 - 4 - 3"},
+		{"negative", []string{"-", "*"}, -6, []int{1, 3, 3}, "-6 = 1 - 3 * 3"},
+		{"power", []string{"+", "**"}, 81, []int{1, 2, 4}, "81 = 1 + 2 ** 4"},
+		{"power of one", []string{"**"}, 1<<60 + 100, []int{1<<60 + 100, 1}, "1152921504606847076 = 1152921504606847076 ** 1"},
+		{"negative root", []string{"**"}, 9, []int{-3, 2}, "9 = -3 ** 2"},
+		{"negative root later", []string{"-", "**"}, 4, []int{1, 3, 2}, "4 = 1 - 3 ** 2"},
+		{"odd negative root", []string{"**"}, -27, []int{-3, 3}, "-27 = -3 ** 3"},
+		{"big root", []string{"**"}, 3037000499 * 3037000499, []int{3037000499, 2}, "9223372030926249001 = 3037000499 ** 2"},
+		{"MinInt root", []string{"**"}, math.MinInt, []int{-2, 63}, "-9223372036854775808 = -2 ** 63"},
+		{"times zero", []string{"*", "+"}, 0, []int{5, 7, 0}, "0 = 5 * 7 * 0"},
+		// 2^40 * 2^40 doesn't fit in an int, so that can't be the way
+		{"overflows", []string{"*"}, 0, []int{1 << 40, 1 << 40, 0}, ""},
+		{"no MinInt times -1", []string{"*"}, math.MinInt, []int{math.MinInt, -1}, ""},
+		{"overflow avoided", []string{"*", "+"}, 0, []int{1 << 40, 1 << 40, 0}, "0 = 1099511627776 + 1099511627776 * 0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ops, err := lookup(tt.symbols...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := ""
+			if chosen, ok := solve(tt.target, tt.values, ops); ok {
+				got = equation{tt.target, tt.values}.expression(chosen)
+			}
+			if got != tt.want {
+				t.Errorf("solve() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_calibrate(t *testing.T) {
+	if _, err := calibrate([]string{"190: 10 19"}, "+", "?"); err == nil {
+		t.Error("calibrate() with an unknown operator should fail")
+	}
+	if _, err := calibrate([]string{"99999999999999999999: 1 2"}, "+"); err == nil {
+		t.Error("calibrate() with a test value too big for an int should fail")
+	}
+	if _, err := calibrate([]string{"9223372036854775807: 9223372036854775807", "1: 1"}, "+"); err == nil {
+		t.Error("calibrate() with a total too big for an int should fail")
+	}
+}
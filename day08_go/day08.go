@@ -0,0 +1,180 @@
+package day08
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/kentquirk/aoc2024/aoc"
+	"github.com/kentquirk/aoc2024/aoc/geom"
+	"github.com/kentquirk/aoc2024/aoc/grid"
+)
+
+type antennaMap struct {
+	g        *grid.Grid[byte]
+	antennas map[byte][]grid.Point // by frequency
+}
+
+// record a slice of positions for each frequency
+func parseAntennaMap(lines []string) (*antennaMap, error) {
+	g, err := grid.Bytes(lines)
+	if err != nil {
+		return nil, err
+	}
+	m := &antennaMap{g: g, antennas: make(map[byte][]grid.Point)}
+	for p, c := range g.All() {
+		if c != '.' {
+			m.antennas[c] = append(m.antennas[c], p)
+		}
+	}
+	return m, nil
+}
+
+// harmonics says where a pair of antennas makes antinodes: at each multiple
+// from min to max of the spacing between them, going outward past each
+// antenna. A max below 0 means all the way to the edge of the map. If
+// reduce is set, the spacing is divided down to the smallest step along the
+// line that lands on grid points, and with a min of 0 the points between
+// the antennas count too, so every grid point in line with both is covered.
+type harmonics struct {
+	min, max int
+	reduce   bool
+}
+
+var (
+	// twice as far from one antenna as the other
+	firstHarmonic = harmonics{min: 1, max: 1}
+	// anywhere in line with the pair
+	allHarmonics = harmonics{min: 0, max: -1, reduce: true}
+)
+
+func gcd(a, b int) int {
+	if a < 0 {
+		a = -a
+	}
+	if b < 0 {
+		b = -b
+	}
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// antinodes returns the antinodes on the map for antennas at p1 and p2
+func (m *antennaMap) antinodes(p1, p2 grid.Point, h harmonics) []grid.Point {
+	step := p1.VectorTo(p2)
+	if h.reduce {
+		if g := gcd(step.X, step.Y); g > 1 {
+			step = geom.Point{X: step.X / g, Y: step.Y / g}
+		}
+	}
+	var found []grid.Point
+	// outward past p2, then back the other way past p1
+	for _, from := range []struct {
+		p    grid.Point
+		step grid.Point
+	}{{p2, step}, {p1, step.Neg()}} {
+		for k := h.min; h.max < 0 || k <= h.max; k++ {
+			a := from.p.Add(from.step.Scale(k))
+			if !m.g.In(a) {
+				break
+			}
+			found = append(found, a)
+		}
+	}
+	if h.reduce && h.min == 0 {
+		for a := p1.Add(step); a != p2; a = a.Add(step) {
+			found = append(found, a)
+		}
+	}
+	return found
+}
+
+// frequencyResult is what one frequency's antennas do
+type frequencyResult struct {
+	freq      byte
+	antennas  []grid.Point
+	antinodes []grid.Point // each one once, in reading order
+}
+
+// findAntinodes works out the antinodes for every frequency, in order of
+// frequency
+func (m *antennaMap) findAntinodes(h harmonics) []frequencyResult {
+	var results []frequencyResult
+	for _, freq := range slices.Sorted(maps.Keys(m.antennas)) {
+		positions := m.antennas[freq]
+		unique := make(map[grid.Point]bool)
+		for i, p1 := range positions {
+			for _, p2 := range positions[i+1:] {
+				for _, a := range m.antinodes(p1, p2, h) {
+					unique[a] = true
+				}
+			}
+		}
+		antinodes := slices.SortedFunc(maps.Keys(unique), func(a, b grid.Point) int {
+			if a.Y != b.Y {
+				return a.Y - b.Y
+			}
+			return a.X - b.X
+		})
+		results = append(results, frequencyResult{freq, positions, antinodes})
+	}
+	return results
+}
+
+// countUnique counts the places with an antinode of any frequency
+func countUnique(results []frequencyResult) int {
+	all := make(map[grid.Point]bool)
+	for _, r := range results {
+		for _, a := range r.antinodes {
+			all[a] = true
+		}
+	}
+	return len(all)
+}
+
+// render draws the map with a '#' on each antinode that isn't under an
+// antenna, the way the puzzle does
+func (m *antennaMap) render(results []frequencyResult) string {
+	overlay := m.g.Clone()
+	for _, r := range results {
+		for _, a := range r.antinodes {
+			if overlay.At(a) == '.' {
+				overlay.Set(a, '#')
+			}
+		}
+	}
+	return overlay.String()
+}
+
+// solve finds the antinodes and returns how many places have one. With
+// "show" in the arguments, as in "aoc run -v 8 show", it prints each
+// frequency's count and the map with the antinodes on it.
+func solve(h harmonics) aoc.Part {
+	return func(in *aoc.Input) (any, error) {
+		show := false
+		for _, arg := range in.Args {
+			if arg != "show" {
+				return nil, fmt.Errorf("unknown argument %q", arg)
+			}
+			show = true
+		}
+		m, err := parseAntennaMap(in.Lines)
+		if err != nil {
+			return nil, err
+		}
+		results := m.findAntinodes(h)
+		if show {
+			for _, r := range results {
+				fmt.Printf("frequency %c: %d antennas, %d antinodes\n", r.freq, len(r.antennas), len(r.antinodes))
+			}
+			fmt.Print(m.render(results))
+		}
+		return countUnique(results), nil
+	}
+}
+
+func init() {
+	aoc.Register(8, aoc.Parts{solve(firstHarmonic), solve(allHarmonics)})
+}
@@ -0,0 +1,96 @@
+package day08
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kentquirk/aoc2024/aoc/aoctest"
+	"github.com/kentquirk/aoc2024/aoc/grid"
+)
+
+func TestAnswers(t *testing.T) {
+	aoctest.Answers(t, 8)
+}
+
+func BenchmarkParts(b *testing.B) {
+	aoctest.Benchmark(b, 8)
+}
+
+func Test_antennaMap_antinodes(t *testing.T) {
+	m, err := parseAntennaMap([]string{
+		"..........",
+		"..........",
+		"..........",
+		"..........",
+		"..........",
+		"..........",
+		"..........",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := func(x, y int) grid.Point { return grid.Point{X: x, Y: y} }
+	tests := []struct {
+		name   string
+		p1, p2 grid.Point
+		h      harmonics
+		want   []grid.Point
+	}{
+		{"first", p(3, 1), p(4, 2), firstHarmonic, []grid.Point{p(5, 3), p(2, 0)}},
+		{"first off the map", p(0, 0), p(2, 4), firstHarmonic, []grid.Point{}},
+		{"second only", p(4, 3), p(5, 3), harmonics{min: 2, max: 2}, []grid.Point{p(7, 3), p(2, 3)}},
+		{"range", p(4, 3), p(5, 3), harmonics{min: 1, max: 3}, []grid.Point{p(6, 3), p(7, 3), p(8, 3), p(3, 3), p(2, 3), p(1, 3)}},
+		{"whole line", p(2, 2), p(4, 3), harmonics{min: 0, max: -1}, []grid.Point{p(4, 3), p(6, 4), p(8, 5), p(2, 2), p(0, 1)}},
+		// (2,2) to (6,2) passes through (3,2), (4,2) and (5,2), which only
+		// count once the spacing is reduced
+		{"unreduced", p(2, 2), p(6, 2), harmonics{min: 0, max: -1}, []grid.Point{p(6, 2), p(2, 2)}},
+		{"reduced", p(2, 2), p(6, 2), allHarmonics, []grid.Point{
+			p(6, 2), p(7, 2), p(8, 2), p(9, 2), p(2, 2), p(1, 2), p(0, 2), p(3, 2), p(4, 2), p(5, 2),
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := m.antinodes(tt.p1, tt.p2, tt.h)
+			if len(got) != len(tt.want) || (len(got) > 0 && !reflect.DeepEqual(got, tt.want)) {
+				t.Errorf("antinodes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_antennaMap_findAntinodes(t *testing.T) {
+	m, err := parseAntennaMap([]string{
+		"..........",
+		"..........",
+		"..........",
+		"....a.....",
+		"........B.",
+		".....a....",
+		"..........",
+		"..........",
+		"..........",
+		"..........",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	results := m.findAntinodes(firstHarmonic)
+	if len(results) != 2 || results[0].freq != 'B' || len(results[0].antinodes) != 0 ||
+		results[1].freq != 'a' || len(results[1].antinodes) != 2 || countUnique(results) != 2 {
+		t.Fatalf("findAntinodes() = %+v", results)
+	}
+	want := `..........
+...#......
+..........
+....a.....
+........B.
+.....a....
+..........
+......#...
+..........
+..........
+`
+	if got := m.render(results); got != want {
+		t.Errorf("render() =\n%s\nwant\n%s", got, want)
+	}
+}
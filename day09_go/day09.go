@@ -0,0 +1,216 @@
+package day09
+
+import (
+	"container/heap"
+	"fmt"
+	"strings"
+
+	"github.com/kentquirk/aoc2024/aoc"
+)
+
+// free is the id of a block with no file in it
+const free = -1
+
+// maxSpan is the biggest a file or a free span can be, since the disk map
+// gives each size as one digit
+const maxSpan = 9
+
+// span is a run of blocks on the disk
+type span struct {
+	offset int
+	size   int
+}
+
+// disk is the layout from the disk map: where each file is, by id, and
+// where the free spans between them are. The puzzle's files are never
+// empty, so two free spans are never next to each other, and if they are
+// they're still treated as separate spans.
+type disk struct {
+	files []span
+	free  []span
+}
+
+func parseDisk(data string) (*disk, error) {
+	d := &disk{}
+	offset := 0
+	for i, c := range []byte(strings.TrimSpace(data)) {
+		if c < '0' || c > '9' {
+			return nil, fmt.Errorf("position %d: %q isn't a digit", i+1, c)
+		}
+		s := span{offset, int(c - '0')}
+		if i%2 == 0 {
+			d.files = append(d.files, s)
+		} else if s.size > 0 {
+			d.free = append(d.free, s)
+		}
+		offset += s.size
+	}
+	return d, nil
+}
+
+// blocks returns the file id in each block of the disk, or free
+func (d *disk) blocks() []int {
+	size := 0
+	if n := len(d.files); n > 0 {
+		size = d.files[n-1].offset + d.files[n-1].size
+	}
+	blocks := make([]int, size)
+	for i := range blocks {
+		blocks[i] = free
+	}
+	for id, f := range d.files {
+		for i := range f.size {
+			blocks[f.offset+i] = id
+		}
+	}
+	return blocks
+}
+
+// compactBlocks moves file blocks one at a time from the end of the disk to
+// the first free block, until there are no gaps. Files can end up in
+// pieces.
+func (d *disk) compactBlocks() []int {
+	blocks := d.blocks()
+	for i, j := 0, len(blocks)-1; i < j; {
+		switch {
+		case blocks[i] != free:
+			i++
+		case blocks[j] == free:
+			j--
+		default:
+			blocks[i], blocks[j] = blocks[j], free
+		}
+	}
+	return blocks
+}
+
+func blocksChecksum(blocks []int) int {
+	ck := 0
+	for i, id := range blocks {
+		if id != free {
+			ck += i * id
+		}
+	}
+	return ck
+}
+
+// offsets is a min-heap of the offsets of free spans
+type offsets []int
+
+func (h offsets) Len() int           { return len(h) }
+func (h offsets) Less(i, j int) bool { return h[i] < h[j] }
+func (h offsets) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *offsets) Push(x any)        { *h = append(*h, x.(int)) }
+func (h *offsets) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// freeSpans keeps the free spans in a heap for each size, so the leftmost
+// span of a size is always at the top of its heap
+type freeSpans [maxSpan + 1]offsets
+
+func newFreeSpans(spans []span) *freeSpans {
+	var f freeSpans
+	for _, s := range spans {
+		f[s.size] = append(f[s.size], s.offset)
+	}
+	for size := range f {
+		heap.Init(&f[size])
+	}
+	return &f
+}
+
+// fit picks the size of free span to put a file of the given size in, from
+// those that start before the file does. It returns false if none will do.
+type fit func(f *freeSpans, size, before int) (int, bool)
+
+// firstFit picks the leftmost span the file fits in
+func firstFit(f *freeSpans, size, before int) (int, bool) {
+	best, found := 0, false
+	for s := size; s <= maxSpan; s++ {
+		if len(f[s]) > 0 && f[s][0] < before && (!found || f[s][0] < f[best][0]) {
+			best, found = s, true
+		}
+	}
+	return best, found
+}
+
+// bestFit picks the smallest span the file fits in, and the leftmost of
+// those
+func bestFit(f *freeSpans, size, before int) (int, bool) {
+	for s := size; s <= maxSpan; s++ {
+		if len(f[s]) > 0 && f[s][0] < before {
+			return s, true
+		}
+	}
+	return 0, false
+}
+
+// moveFiles tries to move each whole file once, highest id first, into a
+// free span further left chosen by pick. It returns where each file ends
+// up. The space a file leaves behind is never used again, since every file
+// still to move starts to the left of it.
+func (d *disk) moveFiles(pick fit) []span {
+	f := newFreeSpans(d.free)
+	files := append([]span(nil), d.files...)
+	for id := len(files) - 1; id >= 0; id-- {
+		file := files[id]
+		if file.size == 0 {
+			continue
+		}
+		s, ok := pick(f, file.size, file.offset)
+		if !ok {
+			continue
+		}
+		offset := heap.Pop(&f[s]).(int)
+		files[id].offset = offset
+		if rest := s - file.size; rest > 0 {
+			heap.Push(&f[rest], offset+file.size)
+		}
+	}
+	return files
+}
+
+func filesChecksum(files []span) int {
+	ck := 0
+	for id, f := range files {
+		// the sum of offset, offset+1, ... offset+size-1
+		ck += id * (f.size*f.offset + f.size*(f.size-1)/2)
+	}
+	return ck
+}
+
+func part1(data string) (int, error) {
+	d, err := parseDisk(data)
+	if err != nil {
+		return 0, err
+	}
+	return blocksChecksum(d.compactBlocks()), nil
+}
+
+// part2 moves whole files to the first space they fit in, or the smallest
+// with "best" in the arguments, as in "aoc run 9 best"
+func part2(data string, args []string) (int, error) {
+	pick := firstFit
+	for _, arg := range args {
+		if arg != "best" {
+			return 0, fmt.Errorf("unknown argument %q", arg)
+		}
+		pick = bestFit
+	}
+	d, err := parseDisk(data)
+	if err != nil {
+		return 0, err
+	}
+	return filesChecksum(d.moveFiles(pick)), nil
+}
+
+func init() {
+	aoc.Register(9, aoc.Parts{
+		func(in *aoc.Input) (any, error) { return part1(in.Text) },
+		func(in *aoc.Input) (any, error) { return part2(in.Text, in.Args) },
+	})
+}
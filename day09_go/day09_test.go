@@ -0,0 +1,103 @@
+package day09
+
+import (
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/kentquirk/aoc2024/aoc/aoctest"
+)
+
+func TestAnswers(t *testing.T) {
+	aoctest.Answers(t, 9)
+}
+
+func BenchmarkParts(b *testing.B) {
+	aoctest.Benchmark(b, 9)
+}
+
+// slowMove moves whole files the obvious way, scanning the blocks from the
+// start for each one, to check moveFiles against. With best set it takes
+// the smallest gap that fits instead of the first.
+func slowMove(d *disk, best bool) int {
+	blocks := d.blocks()
+	for id := len(d.files) - 1; id >= 0; id-- {
+		f := d.files[id]
+		at, atSize := -1, 0
+		for i := 0; i < f.offset; {
+			if blocks[i] != free {
+				i++
+				continue
+			}
+			j := i
+			for j < f.offset && blocks[j] == free {
+				j++
+			}
+			if j-i >= f.size && (at < 0 || (best && j-i < atSize)) {
+				at, atSize = i, j-i
+			}
+			i = j
+		}
+		if f.size == 0 || at < 0 {
+			continue
+		}
+		for k := range f.size {
+			blocks[at+k], blocks[f.offset+k] = id, free
+		}
+	}
+	return blocksChecksum(blocks)
+}
+
+func Test_disk_moveFiles(t *testing.T) {
+	rnd := rand.New(rand.NewPCG(9, 9))
+	for n := range 500 {
+		var sb strings.Builder
+		// files always have something in them, but free spans can be empty
+		for i := range 1 + rnd.IntN(40) {
+			if i%2 == 0 {
+				sb.WriteByte(byte('1' + rnd.IntN(9)))
+			} else {
+				sb.WriteByte(byte('0' + rnd.IntN(10)))
+			}
+		}
+		d, err := parseDisk(sb.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, tt := range []struct {
+			name string
+			pick fit
+			best bool
+		}{{"first", firstFit, false}, {"best", bestFit, true}} {
+			if got, want := filesChecksum(d.moveFiles(tt.pick)), slowMove(d, tt.best); got != want {
+				t.Fatalf("disk %d %q, %s fit: checksum %d, want %d", n, sb.String(), tt.name, got, want)
+			}
+		}
+	}
+}
+
+func Test_part2(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		args    []string
+		want    int
+		wantErr bool
+	}{
+		{"first fit", "2333133121414131402\n", nil, 2858, false},
+		// file 2 goes in the gap of 2 instead of the gap of 3, which leaves
+		// room for file 1
+		{"best fit", "13221", []string{"best"}, 15, false},
+		{"first fit again", "13221", nil, 7, false},
+		{"not a disk map", "12x4", nil, 0, true},
+		{"bad argument", "12", []string{"worst"}, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := part2(tt.data, tt.args)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("part2() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,286 @@
+package day10
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kentquirk/aoc2024/aoc"
+	"github.com/kentquirk/aoc2024/aoc/grid"
+)
+
+type position = grid.Point
+
+// digits are the heights on a normal map, lowest first
+const digits = "0123456789"
+
+// impassable is the height of a cell that can't be walked on, like the '.'s
+// in some of the examples
+const impassable = -1
+
+// terrain is a topographic map. Trailheads are at the lowest height and
+// summits at the highest.
+type terrain struct {
+	heights *grid.Grid[int]
+	top     int
+}
+
+// parseTerrain reads a map whose heights are the characters of alphabet,
+// lowest first. A '.' is a cell that can't be walked on.
+func parseTerrain(lines []string, alphabet string) (*terrain, error) {
+	// with one height, trailheads and summits would be the same cells
+	if len(alphabet) < 2 {
+		return nil, fmt.Errorf("alphabet %q needs at least two heights", alphabet)
+	}
+	var bad error
+	heights, err := grid.Parse(lines, func(p position, b byte) int {
+		h := strings.IndexByte(alphabet, b)
+		if h < 0 && b != '.' && bad == nil {
+			bad = fmt.Errorf("line %d, column %d: %q isn't a height", p.Y+1, p.X+1, b)
+		}
+		return h
+	})
+	if err == nil {
+		err = bad
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &terrain{heights: heights, top: len(alphabet) - 1}, nil
+}
+
+// climb says which steps a trail can take: the height can change by
+// anything from minRise to maxRise
+type climb struct {
+	minRise, maxRise int
+}
+
+var oneUp = climb{1, 1}
+
+func (c climb) allows(from, to int) bool {
+	return from != impassable && to != impassable && to-from >= c.minRise && to-from <= c.maxRise
+}
+
+// ascending reports whether every step goes up, so a trail can never come
+// back to where it's been
+func (c climb) ascending() bool {
+	return c.minRise > 0
+}
+
+// summitSet is a set of summits, as a bit for each
+type summitSet []uint64
+
+func newSummitSet(n int) summitSet {
+	return make(summitSet, (n+63)/64)
+}
+
+func (s summitSet) add(i int) {
+	s[i/64] |= 1 << (i % 64)
+}
+
+func (s summitSet) union(t summitSet) {
+	for i := range s {
+		s[i] |= t[i]
+	}
+}
+
+func (s summitSet) len() int {
+	n := 0
+	for _, w := range s {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// members returns the index of each summit in the set, in order
+func (s summitSet) members() []int {
+	var m []int
+	for i, w := range s {
+		for ; w != 0; w &= w - 1 {
+			m = append(m, i*64+bits.TrailingZeros64(w))
+		}
+	}
+	return m
+}
+
+// trailhead is what can be done from one trailhead: its score is the
+// number of summits it can reach, and its rating is the number of different
+// trails to them. The rating is -1 if trails could go round in circles.
+type trailhead struct {
+	at      position
+	score   int
+	rating  int
+	summits []position
+}
+
+// analyze works out every trailhead's score and rating, in reading order.
+//
+// If every step has to go up, the cells are taken highest first, so each
+// one can add up the summits and trails of the cells it steps to, which
+// have already been done. Otherwise trails can double back, and each
+// trailhead does a search of its own.
+func (t *terrain) analyze(rule climb) []trailhead {
+	var heads, summits []position
+	summitIndex := make(map[position]int)
+	for p, h := range t.heights.All() {
+		switch h {
+		case 0:
+			heads = append(heads, p)
+		case t.top:
+			summitIndex[p] = len(summits)
+			summits = append(summits, p)
+		}
+	}
+
+	reach := grid.New[summitSet](t.heights.Width, t.heights.Height)
+	routes := grid.New[int](t.heights.Width, t.heights.Height)
+	if rule.ascending() {
+		var cells []position
+		for p, h := range t.heights.All() {
+			if h != impassable {
+				cells = append(cells, p)
+			}
+		}
+		slices.SortStableFunc(cells, func(a, b position) int {
+			return t.heights.At(b) - t.heights.At(a)
+		})
+		for _, p := range cells {
+			r := newSummitSet(len(summits))
+			if i, ok := summitIndex[p]; ok {
+				r.add(i)
+				routes.Set(p, 1)
+			}
+			for q, h := range t.heights.Neighbors4(p) {
+				if rule.allows(t.heights.At(p), h) {
+					r.union(reach.At(q))
+					routes.Set(p, routes.At(p)+routes.At(q))
+				}
+			}
+			reach.Set(p, r)
+		}
+	} else {
+		for _, p := range heads {
+			reach.Set(p, t.reachable(p, rule, summitIndex, len(summits)))
+			routes.Set(p, -1)
+		}
+	}
+
+	report := make([]trailhead, len(heads))
+	for i, p := range heads {
+		r := reach.At(p)
+		th := trailhead{at: p, score: r.len(), rating: routes.At(p)}
+		for _, s := range r.members() {
+			th.summits = append(th.summits, summits[s])
+		}
+		report[i] = th
+	}
+	return report
+}
+
+// reachable finds the summits that can be reached from p, going anywhere
+// the rule allows
+func (t *terrain) reachable(p position, rule climb, summitIndex map[position]int, nsummits int) summitSet {
+	r := newSummitSet(nsummits)
+	seen := map[position]bool{p: true}
+	queue := []position{p}
+	for len(queue) > 0 {
+		p, queue = queue[0], queue[1:]
+		if i, ok := summitIndex[p]; ok {
+			r.add(i)
+		}
+		for q, h := range t.heights.Neighbors4(p) {
+			if !seen[q] && rule.allows(t.heights.At(p), h) {
+				seen[q] = true
+				queue = append(queue, q)
+			}
+		}
+	}
+	return r
+}
+
+// climbArgs reads the rule from the arguments: the smallest and largest
+// change in height allowed, as in "aoc run 10 1 3". With none, every step
+// goes up by exactly 1.
+func climbArgs(args []string) (climb, error) {
+	switch len(args) {
+	case 0:
+		return oneUp, nil
+	case 2:
+		lo, err1 := strconv.Atoi(args[0])
+		hi, err2 := strconv.Atoi(args[1])
+		if err1 != nil || err2 != nil || lo > hi {
+			return climb{}, fmt.Errorf("bad rise range %v", args)
+		}
+		return climb{lo, hi}, nil
+	}
+	return climb{}, errors.New("expected the smallest and largest rise")
+}
+
+// alphabetArg takes the heights from an "alphabet=" argument, as in
+// "aoc run 10 alphabet=abcdefghij", and returns the other arguments. Without
+// one, the heights are digits.
+func alphabetArg(args []string) (string, []string) {
+	alphabet := digits
+	var rest []string
+	for _, arg := range args {
+		if a, ok := strings.CutPrefix(arg, "alphabet="); ok {
+			alphabet = a
+			continue
+		}
+		rest = append(rest, arg)
+	}
+	return alphabet, rest
+}
+
+// report analyzes the map and prints each trailhead, so "aoc run -v 10"
+// shows where the trails go
+func report(in *aoc.Input) ([]trailhead, error) {
+	alphabet, args := alphabetArg(in.Args)
+	rule, err := climbArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	t, err := parseTerrain(in.Lines, alphabet)
+	if err != nil {
+		return nil, err
+	}
+	heads := t.analyze(rule)
+	for _, th := range heads {
+		fmt.Printf("%v: score %d, rating %d, summits %v\n", th.at, th.score, th.rating, th.summits)
+	}
+	return heads, nil
+}
+
+func part1(in *aoc.Input) (any, error) {
+	heads, err := report(in)
+	if err != nil {
+		return nil, err
+	}
+	total := 0
+	for _, th := range heads {
+		total += th.score
+	}
+	return total, nil
+}
+
+func part2(in *aoc.Input) (any, error) {
+	heads, err := report(in)
+	if err != nil {
+		return nil, err
+	}
+	total := 0
+	for _, th := range heads {
+		if th.rating < 0 {
+			return nil, errors.New("trails can go round in circles, so there's no rating")
+		}
+		total += th.rating
+	}
+	return total, nil
+}
+
+func init() {
+	aoc.Register(10, aoc.Parts{part1, part2})
+}
@@ -0,0 +1,79 @@
+package day10
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kentquirk/aoc2024/aoc"
+	"github.com/kentquirk/aoc2024/aoc/aoctest"
+)
+
+func TestAnswers(t *testing.T) {
+	aoctest.Answers(t, 10)
+}
+
+func BenchmarkParts(b *testing.B) {
+	aoctest.Benchmark(b, 10)
+}
+
+func Test_terrain_analyze(t *testing.T) {
+	p := func(x, y int) position { return position{X: x, Y: y} }
+	tests := []struct {
+		name     string
+		lines    []string
+		alphabet string
+		rule     climb
+		want     []trailhead
+	}{
+		{"one trail", []string{"0123", "...4", "...5", "9876"}, "0123456789", oneUp,
+			[]trailhead{{p(0, 0), 1, 1, []position{p(0, 3)}}}},
+		{"two ways up", []string{"012", "123", "234"}, "01234", oneUp,
+			[]trailhead{{p(0, 0), 1, 6, []position{p(2, 2)}}}},
+		{"letters", []string{"abc", "..c", "cba"}, "abc", oneUp,
+			[]trailhead{{p(0, 0), 1, 1, []position{p(2, 0)}}, {p(2, 2), 1, 1, []position{p(0, 2)}}}},
+		{"big steps", []string{"0369", "...."}, "0123456789", climb{1, 3},
+			[]trailhead{{p(0, 0), 1, 1, []position{p(3, 0)}}}},
+		{"too big", []string{"0369", "...."}, "0123456789", climb{1, 2},
+			[]trailhead{{p(0, 0), 0, 0, nil}}},
+		// going down again means trails can loop, so there's no rating
+		{"up and down", []string{"01012", "....."}, "012", climb{-1, 1},
+			[]trailhead{{p(0, 0), 1, -1, []position{p(4, 0)}}, {p(2, 0), 1, -1, []position{p(4, 0)}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ter, err := parseTerrain(tt.lines, tt.alphabet)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// twice, to check nothing is left over from the first time
+			for range 2 {
+				if got := ter.analyze(tt.rule); !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("analyze() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+
+	if _, err := parseTerrain([]string{"01x"}, digits); err == nil {
+		t.Error("parseTerrain() should fail on a character that isn't a height")
+	}
+	if _, err := parseTerrain([]string{"00"}, "0"); err == nil {
+		t.Error("parseTerrain() should fail with only one height")
+	}
+}
+
+func Test_alphabetArg(t *testing.T) {
+	in := &aoc.Input{Lines: []string{"abc", "..c", "cba"}, Args: []string{"alphabet=abc"}}
+	if got, err := part1(in); err != nil || got != 2 {
+		t.Errorf("part1() with letters = %v, %v, want 2", got, err)
+	}
+	// the alphabet can go with a rule
+	in = &aoc.Input{Lines: []string{"acb"}, Args: []string{"1", "2", "alphabet=abc"}}
+	if got, err := part1(in); err != nil || got != 1 {
+		t.Errorf("part1() with letters and a rule = %v, %v, want 1", got, err)
+	}
+	in.Args = []string{"alphabet=a"}
+	if _, err := part1(in); err == nil {
+		t.Error("part1() should fail with only one height")
+	}
+}
@@ -0,0 +1,350 @@
+package day12
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/kentquirk/aoc2024/aoc"
+	"github.com/kentquirk/aoc2024/aoc/geom"
+	"github.com/kentquirk/aoc2024/aoc/grid"
+)
+
+// region is a patch of one kind of plant, and what it takes to fence it
+type region struct {
+	id        int
+	plant     byte
+	start     grid.Point // the first plot in reading order
+	area      int
+	perimeter int
+	sides     int
+	// holes is how many separate patches of other plants it surrounds, and
+	// enclaves are the ids of the regions in them
+	holes    int
+	enclaves []int
+	min, max grid.Point // the corners of the smallest box around it
+}
+
+// garden is the map of plants, split up into regions
+type garden struct {
+	plants   *grid.Grid[byte]
+	regionOf *grid.Grid[int] // the id of the region each plot is in
+	regions  []region
+}
+
+// sets is a union-find over the plots, keeping a running total of each
+// set's area, perimeter and corners as sets are joined
+type sets struct {
+	parent []int
+	stats  []region
+}
+
+func (s *sets) find(i int) int {
+	for s.parent[i] != i {
+		s.parent[i] = s.parent[s.parent[i]]
+		i = s.parent[i]
+	}
+	return i
+}
+
+func (s *sets) union(i, j int) {
+	i, j = s.find(i), s.find(j)
+	if i == j {
+		return
+	}
+	// keep the root that comes first in reading order, which also keeps
+	// the trees shallow enough since plots are joined in that order
+	if j < i {
+		i, j = j, i
+	}
+	s.parent[j] = i
+	a, b := &s.stats[i], s.stats[j]
+	a.area += b.area
+	a.perimeter += b.perimeter
+	a.sides += b.sides
+	a.min = geom.Point{X: min(a.min.X, b.min.X), Y: min(a.min.Y, b.min.Y)}
+	a.max = geom.Point{X: max(a.max.X, b.max.X), Y: max(a.max.Y, b.max.Y)}
+}
+
+// corners are the directions to check at each corner of a plot: the two
+// neighbours on either side of it and the one diagonally across
+var corners = [4][3]geom.Dir8{
+	{geom.N, geom.E, geom.NE},
+	{geom.E, geom.S, geom.SE},
+	{geom.S, geom.W, geom.SW},
+	{geom.W, geom.N, geom.NW},
+}
+
+func newGarden(lines []string) (*garden, error) {
+	plants, err := grid.Bytes(lines)
+	if err != nil {
+		return nil, err
+	}
+	w, h := plants.Width, plants.Height
+	s := &sets{parent: make([]int, w*h), stats: make([]region, w*h)}
+	same := func(p grid.Point, d geom.Dir8) bool {
+		plant, ok := plants.Get(p.Move8(d))
+		return ok && plant == plants.At(p)
+	}
+
+	// one pass: each plot adds its own area, fences and corners, and joins
+	// up with the plots above and to the left if they're the same plant
+	for p, plant := range plants.All() {
+		i := p.Y*w + p.X
+		st := region{plant: plant, start: p, area: 1, min: p, max: p}
+		for _, d := range []geom.Dir8{geom.N, geom.E, geom.S, geom.W} {
+			if !same(p, d) {
+				st.perimeter++
+			}
+		}
+		// a region has as many sides as corners: a plot's corner is an
+		// outside corner if neither neighbour matches, and an inside corner
+		// if both do but the diagonal doesn't
+		for _, c := range corners {
+			a, b, diag := same(p, c[0]), same(p, c[1]), same(p, c[2])
+			if (!a && !b) || (a && b && !diag) {
+				st.sides++
+			}
+		}
+		s.parent[i], s.stats[i] = i, st
+		if same(p, geom.N) {
+			s.union(i, i-w)
+		}
+		if same(p, geom.W) {
+			s.union(i, i-1)
+		}
+	}
+
+	g := &garden{plants: plants, regionOf: grid.New[int](w, h)}
+	ids := make(map[int]int)
+	for p := range plants.All() {
+		root := s.find(p.Y*w + p.X)
+		id, ok := ids[root]
+		if !ok {
+			id = len(g.regions)
+			ids[root] = id
+			st := s.stats[root]
+			st.id = id
+			g.regions = append(g.regions, st)
+		}
+		g.regionOf.Set(p, id)
+	}
+	g.findHoles()
+	return g, nil
+}
+
+// findHoles counts each region's holes with Euler's formula: a shape made
+// of squares has (pieces - holes) = corners - edges + squares. Each region
+// is in one piece, so that gives the holes once the distinct lattice
+// corners of each region are counted. Then the regions with holes are
+// searched to see which other regions are in them.
+func (g *garden) findHoles() {
+	vertices := make([]int, len(g.regions))
+	for y := 0; y <= g.plants.Height; y++ {
+		for x := 0; x <= g.plants.Width; x++ {
+			// the up to four plots that meet at this corner
+			var seen [4]int
+			n := 0
+		plots:
+			for _, p := range []grid.Point{{X: x - 1, Y: y - 1}, {X: x, Y: y - 1}, {X: x - 1, Y: y}, {X: x, Y: y}} {
+				id, ok := g.regionOf.Get(p)
+				if !ok {
+					continue
+				}
+				for _, other := range seen[:n] {
+					if other == id {
+						continue plots
+					}
+				}
+				seen[n] = id
+				n++
+				vertices[id]++
+			}
+		}
+	}
+	for i := range g.regions {
+		r := &g.regions[i]
+		// edges = 2 * area + perimeter / 2, since each plot has 4 edges and
+		// the ones inside the region are shared by two plots
+		r.holes = 1 - vertices[i] + r.area + r.perimeter/2
+		if r.holes > 0 {
+			r.enclaves = g.enclaves(r)
+		}
+	}
+}
+
+// enclaves finds the regions inside r's holes. It fills in from the edge
+// of a box one plot bigger than r all round; whatever isn't reached and
+// isn't r is in a hole.
+func (g *garden) enclaves(r *region) []int {
+	lo := r.min.Sub(geom.Point{X: 1, Y: 1})
+	hi := r.max.Add(geom.Point{X: 1, Y: 1})
+	inBox := func(p grid.Point) bool {
+		return p.X >= lo.X && p.X <= hi.X && p.Y >= lo.Y && p.Y <= hi.Y
+	}
+	outside := make(map[grid.Point]bool)
+	var queue []grid.Point
+	for y := lo.Y; y <= hi.Y; y++ {
+		for x := lo.X; x <= hi.X; x++ {
+			if x == lo.X || x == hi.X || y == lo.Y || y == hi.Y {
+				p := grid.Point{X: x, Y: y}
+				outside[p] = true
+				queue = append(queue, p)
+			}
+		}
+	}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		for _, d := range geom.Dirs4 {
+			q := p.Move(d)
+			if inBox(q) && !outside[q] && g.regionOf.At(q) != r.id {
+				outside[q] = true
+				queue = append(queue, q)
+			}
+		}
+	}
+
+	var found []int
+	seen := make(map[int]bool)
+	for y := r.min.Y; y <= r.max.Y; y++ {
+		for x := r.min.X; x <= r.max.X; x++ {
+			p := grid.Point{X: x, Y: y}
+			if id := g.regionOf.At(p); id != r.id && !outside[p] && !seen[id] {
+				seen[id] = true
+				found = append(found, id)
+			}
+		}
+	}
+	return found
+}
+
+// side is one straight run of fence along one side of a region. wall says
+// which side of the plots it's on, and from and to are the first and last
+// plots it runs along.
+type side struct {
+	region   int
+	wall     geom.Dir4
+	from, to grid.Point
+}
+
+// fenced reports whether the plot at p needs a fence on its d side
+func (g *garden) fenced(p grid.Point, d geom.Dir4) bool {
+	id, ok := g.regionOf.Get(p.Move(d))
+	return !ok || id != g.regionOf.At(p)
+}
+
+// sides lists every straight run of fence. The plots are visited along
+// each row for the fences above and below them, and down each column for
+// the ones to either side, so a run is just a stretch of neighbouring plots
+// of the same region that all need a fence on that side.
+func (g *garden) sides() []side {
+	var all []side
+	w, h := g.plants.Width, g.plants.Height
+	for _, d := range geom.Dirs4 {
+		lines, length := h, w
+		at := func(line, i int) grid.Point { return grid.Point{X: i, Y: line} }
+		if d == geom.Left || d == geom.Right {
+			lines, length = w, h
+			at = func(line, i int) grid.Point { return grid.Point{X: line, Y: i} }
+		}
+		for line := range lines {
+			open := -1 // the side the last plot added to, if any
+			for i := range length {
+				p := at(line, i)
+				switch {
+				case !g.fenced(p, d):
+					open = -1
+				case open >= 0 && all[open].region == g.regionOf.At(p):
+					all[open].to = p
+				default:
+					all = append(all, side{region: g.regionOf.At(p), wall: d, from: p, to: p})
+					open = len(all) - 1
+				}
+			}
+		}
+	}
+	return all
+}
+
+// price adds up the cost of fencing every region, given the cost of one
+func (g *garden) price(cost func(r region) int) int {
+	total := 0
+	for _, r := range g.regions {
+		total += cost(r)
+	}
+	return total
+}
+
+func (r region) String() string {
+	s := fmt.Sprintf("region %d: %c at %v, area %d, perimeter %d, sides %d", r.id, r.plant, r.start, r.area, r.perimeter, r.sides)
+	if r.holes > 0 {
+		s += fmt.Sprintf(", %d holes holding regions %v", r.holes, r.enclaves)
+	}
+	return s
+}
+
+// fencing prices the garden's fences. With "show" in the arguments, as in
+// "aoc run -v 12 show", it prints every region first. "svg=FILE" and
+// "png=FILE" draw the regions and their fences to a file, and "labels"
+// writes each region's area, perimeter and sides on it.
+func fencing(cost func(r region) int) aoc.Part {
+	return func(in *aoc.Input) (any, error) {
+		show, labels := false, false
+		var svgFile, pngFile string
+		for _, arg := range in.Args {
+			switch name, value, _ := strings.Cut(arg, "="); {
+			case arg == "show":
+				show = true
+			case arg == "labels":
+				labels = true
+			case name == "svg" && value != "":
+				svgFile = value
+			case name == "png" && value != "":
+				pngFile = value
+			default:
+				return nil, fmt.Errorf("unknown argument %q", arg)
+			}
+		}
+		g, err := newGarden(in.Lines)
+		if err != nil {
+			return nil, err
+		}
+		if show {
+			for _, r := range g.regions {
+				fmt.Println(r)
+			}
+		}
+		if svgFile != "" {
+			if err := writeFile(svgFile, func(w io.Writer) error { return g.writeSVG(w, labels) }); err != nil {
+				return nil, err
+			}
+		}
+		if pngFile != "" {
+			if err := writeFile(pngFile, func(w io.Writer) error { return g.writePNG(w, labels) }); err != nil {
+				return nil, err
+			}
+		}
+		return g.price(cost), nil
+	}
+}
+
+func writeFile(name string, write func(w io.Writer) error) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func init() {
+	aoc.Register(12, aoc.Parts{
+		fencing(func(r region) int { return r.area * r.perimeter }),
+		fencing(func(r region) int { return r.area * r.sides }),
+	})
+}
@@ -0,0 +1,187 @@
+package day12
+
+import (
+	"bytes"
+	"encoding/xml"
+	"image"
+	"image/png"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kentquirk/aoc2024/aoc/aoctest"
+)
+
+func TestAnswers(t *testing.T) {
+	aoctest.Answers(t, 12)
+}
+
+func BenchmarkParts(b *testing.B) {
+	aoctest.Benchmark(b, 12)
+}
+
+func Test_newGarden(t *testing.T) {
+	tests := []struct {
+		name      string
+		lines     []string
+		perimeter int // price by perimeter
+		sides     int // price by sides
+	}{
+		{"small", []string{"AAAA", "BBCD", "BBCC", "EEEC"}, 140, 80},
+		{"islands", []string{"OOOOO", "OXOXO", "OOOOO", "OXOXO", "OOOOO"}, 772, 436},
+		{"E", []string{"EEEEE", "EXXXX", "EEEEE", "EXXXX", "EEEEE"}, 692, 236},
+		{"touching holes", []string{"AAAAAA", "AAABBA", "AAABBA", "ABBAAA", "ABBAAA", "AAAAAA"}, 1184, 368},
+		{"ring", []string{"AAA", "A.A", "AAA"}, 8*16 + 4, 8*8 + 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := newGarden(tt.lines)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := g.price(func(r region) int { return r.area * r.perimeter }); got != tt.perimeter {
+				t.Errorf("price by perimeter = %d, want %d", got, tt.perimeter)
+			}
+			if got := g.price(func(r region) int { return r.area * r.sides }); got != tt.sides {
+				t.Errorf("price by sides = %d, want %d", got, tt.sides)
+			}
+		})
+	}
+}
+
+func Test_garden_holes(t *testing.T) {
+	tests := []struct {
+		name     string
+		lines    []string
+		holes    int
+		enclaves []int
+	}{
+		{"none", []string{"AAB", "AAB"}, 0, nil},
+		{"islands", []string{"OOOOO", "OXOXO", "OOOOO", "OXOXO", "OOOOO"}, 4, []int{1, 2, 3, 4}},
+		// the Bs only touch at a corner, so they're two holes
+		{"touching holes", []string{"AAAAAA", "AAABBA", "AAABBA", "ABBAAA", "ABBAAA", "AAAAAA"}, 2, []int{1, 2}},
+		{"two in one hole", []string{"AAAA", "ABCA", "AAAA"}, 1, []int{1, 2}},
+		{"nested", []string{"AAAAA", "ABBBA", "ABCBA", "ABBBA", "AAAAA"}, 1, []int{1, 2}},
+		{"open", []string{"AAA", "A.A", "A.A"}, 0, nil},
+		// the region pinches in between two holes that meet at a corner
+		{"pinched", []string{"AAAA", "A.AA", "AA.A", "AAAA"}, 2, []int{1, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := newGarden(tt.lines)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if r := g.regions[0]; r.holes != tt.holes || !reflect.DeepEqual(r.enclaves, tt.enclaves) {
+				t.Errorf("region 0 = %v, want %d holes holding %v", r, tt.holes, tt.enclaves)
+			}
+		})
+	}
+}
+
+func Test_newGardenBig(t *testing.T) {
+	// one huge region is no trouble without recursion
+	lines := make([]string, 1000)
+	for i := range lines {
+		lines[i] = strings.Repeat("A", 1000)
+	}
+	g, err := newGarden(lines)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(g.regions) != 1 || g.regions[0].area != 1000000 || g.regions[0].sides != 4 {
+		t.Errorf("regions = %v", g.regions)
+	}
+}
+
+func Test_garden_sides(t *testing.T) {
+	for _, lines := range [][]string{
+		{"AAAA", "BBCD", "BBCC", "EEEC"},
+		{"EEEEE", "EXXXX", "EEEEE", "EXXXX", "EEEEE"},
+		{"AAAAAA", "AAABBA", "AAABBA", "ABBAAA", "ABBAAA", "AAAAAA"},
+		{"AAAA", "A.AA", "AA.A", "AAAA"},
+	} {
+		g, err := newGarden(lines)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// the runs of fence should agree with the corner count
+		count := make([]int, len(g.regions))
+		for _, s := range g.sides() {
+			count[s.region]++
+		}
+		for _, r := range g.regions {
+			if count[r.id] != r.sides {
+				t.Errorf("%v: region %d has %d runs of fence, want %d", lines, r.id, count[r.id], r.sides)
+			}
+		}
+	}
+}
+
+func Test_side_line(t *testing.T) {
+	g, err := newGarden([]string{"AAAA", "BBCD", "BBCC", "EEEC"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, s := range g.sides() {
+		if s.region == 0 {
+			from, to := s.line()
+			got = append(got, from.String()+"-"+to.String())
+		}
+	}
+	// the A row's top, right, bottom and left
+	want := []string{"(0,0)-(4,0)", "(4,0)-(4,1)", "(0,1)-(4,1)", "(0,0)-(0,1)"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("A's fences = %v, want %v", got, want)
+	}
+}
+
+func Test_garden_write(t *testing.T) {
+	g, err := newGarden([]string{"AAAA", "BBCD", "BBCC", "EEEC"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var svg strings.Builder
+	if err := g.writeSVG(&svg, true); err != nil {
+		t.Fatal(err)
+	}
+	for elem, want := range map[string]int{"<rect ": 16, "<line ": 24, "<text ": 5} {
+		if got := strings.Count(svg.String(), elem); got != want {
+			t.Errorf("svg has %d %q, want %d", got, elem, want)
+		}
+	}
+	if !strings.Contains(svg.String(), "C 4/10/8") {
+		t.Errorf("svg doesn't label C's area, perimeter and sides")
+	}
+
+	// plants that have to be escaped should still make valid XML
+	odd, err := newGarden([]string{"<<&", "\"'&"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var oddSVG strings.Builder
+	if err := odd.writeSVG(&oddSVG, true); err != nil {
+		t.Fatal(err)
+	}
+	for d := xml.NewDecoder(strings.NewReader(oddSVG.String())); ; {
+		if _, err := d.Token(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("svg isn't valid XML: %v", err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := g.writePNG(&buf, true); err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := img.Bounds().Size(), (image.Point{X: 4*plotSize + 2*margin, Y: 4*plotSize + 2*margin}); got != want {
+		t.Errorf("png is %v, want %v", got, want)
+	}
+}
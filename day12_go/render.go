@@ -0,0 +1,184 @@
+package day12
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+
+	"github.com/kentquirk/aoc2024/aoc/geom"
+)
+
+const (
+	plotSize   = 12 // pixels across a plot
+	fenceWidth = 4  // pixels across a fence, even so it can sit centred on a plot's edge
+	// margin leaves room round the map for the half of the fences along its
+	// edge that's outside it
+	margin = fenceWidth / 2
+)
+
+var (
+	fenceColor = color.RGBA{0x20, 0x20, 0x20, 0xff}
+	labelColor = color.RGBA{0x00, 0x00, 0x00, 0xff}
+)
+
+// regionColor picks a colour for a region. The hues go round by the golden
+// angle, so regions with nearby ids, which are usually nearby on the map
+// too, come out looking quite different.
+func regionColor(id int) color.RGBA {
+	hue := math.Mod(float64(id)*137.508, 360)
+	// lighter and darker in turn, to help when the hues are close anyway
+	light := 0.75
+	if id%2 == 1 {
+		light = 0.6
+	}
+	return hsl(hue, 0.65, light)
+}
+
+func hsl(h, s, l float64) color.RGBA {
+	c := (1 - math.Abs(2*l-1)) * s
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g = c, x
+	case h < 120:
+		r, g = x, c
+	case h < 180:
+		g, b = c, x
+	case h < 240:
+		g, b = x, c
+	case h < 300:
+		r, b = x, c
+	default:
+		r, b = c, x
+	}
+	m := l - c/2
+	scale := func(v float64) uint8 { return uint8(math.Round((v + m) * 255)) }
+	return color.RGBA{scale(r), scale(g), scale(b), 0xff}
+}
+
+// line returns the ends of a side, in plots from the top left corner of
+// the map
+func (s side) line() (from, to geom.Point) {
+	from, to = s.from, s.to.Add(geom.Point{X: 1, Y: 1})
+	switch s.wall {
+	case geom.Up:
+		to.Y = from.Y
+	case geom.Down:
+		from.Y = to.Y
+	case geom.Left:
+		to.X = from.X
+	case geom.Right:
+		from.X = to.X
+	}
+	return from, to
+}
+
+// label is what gets written on a region: its area, perimeter and sides
+func (r region) label() string {
+	return fmt.Sprintf("%d/%d/%d", r.area, r.perimeter, r.sides)
+}
+
+// writeSVG draws the garden as an SVG, with each region in its own colour
+// and a thick line for each side of fence. With labels, each region's
+// label is written on its first plot.
+func (g *garden) writeSVG(w io.Writer, labels bool) error {
+	bw := bufio.NewWriter(w)
+	width, height := g.plants.Width*plotSize+2*margin, g.plants.Height*plotSize+2*margin
+	fmt.Fprintf(bw, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"%d %d %d %d\">\n",
+		width, height, -margin, -margin, width, height)
+	for p, id := range g.regionOf.All() {
+		c := regionColor(id)
+		fmt.Fprintf(bw, "<rect x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\" fill=\"#%02x%02x%02x\"/>\n",
+			p.X*plotSize, p.Y*plotSize, plotSize, plotSize, c.R, c.G, c.B)
+	}
+	fmt.Fprintf(bw, "<g stroke=\"#%02x%02x%02x\" stroke-width=\"%d\" stroke-linecap=\"square\">\n", fenceColor.R, fenceColor.G, fenceColor.B, fenceWidth)
+	for _, s := range g.sides() {
+		from, to := s.line()
+		fmt.Fprintf(bw, "<line x1=\"%d\" y1=\"%d\" x2=\"%d\" y2=\"%d\"/>\n",
+			from.X*plotSize, from.Y*plotSize, to.X*plotSize, to.Y*plotSize)
+	}
+	fmt.Fprintln(bw, "</g>")
+	if labels {
+		fmt.Fprintf(bw, "<g font-family=\"monospace\" font-size=\"%d\">\n", plotSize*3/4)
+		for _, r := range g.regions {
+			// a plant can be any byte, including ones that mean something in XML
+			fmt.Fprintf(bw, "<text x=\"%d\" y=\"%d\"><title>%s</title>%s</text>\n",
+				r.start.X*plotSize+fenceWidth, (r.start.Y+1)*plotSize-fenceWidth,
+				html.EscapeString(r.String()), html.EscapeString(fmt.Sprintf("%c %s", r.plant, r.label())))
+		}
+		fmt.Fprintln(bw, "</g>")
+	}
+	fmt.Fprintln(bw, "</svg>")
+	return bw.Flush()
+}
+
+// digits is a tiny font for the labels on a PNG, since the standard library
+// can't draw text. Each glyph is 3 pixels wide and 5 high, a row to a
+// string.
+var digits = map[rune][5]string{
+	'0': {"###", "#.#", "#.#", "#.#", "###"},
+	'1': {".#.", "##.", ".#.", ".#.", "###"},
+	'2': {"###", "..#", "###", "#..", "###"},
+	'3': {"###", "..#", ".##", "..#", "###"},
+	'4': {"#.#", "#.#", "###", "..#", "..#"},
+	'5': {"###", "#..", "###", "..#", "###"},
+	'6': {"###", "#..", "###", "#.#", "###"},
+	'7': {"###", "..#", "..#", ".#.", ".#."},
+	'8': {"###", "#.#", "###", "#.#", "###"},
+	'9': {"###", "#.#", "###", "..#", "###"},
+	'/': {"..#", "..#", ".#.", "#..", "#.."},
+}
+
+// drawText draws s with its top left corner at x, y. Anything not in the
+// font is left as a gap.
+func drawText(img *image.RGBA, x, y int, s string, c color.RGBA) {
+	for _, ch := range s {
+		for dy, row := range digits[ch] {
+			for dx, px := range row {
+				if px == '#' {
+					img.SetRGBA(x+dx, y+dy, c)
+				}
+			}
+		}
+		x += 4
+	}
+}
+
+// fill paints the rectangle from x0, y0 up to but not including x1, y1
+func fill(img *image.RGBA, x0, y0, x1, y1 int, c color.RGBA) {
+	for y := y0; y < y1; y++ {
+		for x := x0; x < x1; x++ {
+			img.SetRGBA(x, y, c)
+		}
+	}
+}
+
+// writePNG draws the same picture as writeSVG, as a PNG. The labels are
+// just the numbers, in a tiny font.
+func (g *garden) writePNG(w io.Writer, labels bool) error {
+	img := image.NewRGBA(image.Rect(0, 0, g.plants.Width*plotSize+2*margin, g.plants.Height*plotSize+2*margin))
+	for p, id := range g.regionOf.All() {
+		x, y := p.X*plotSize+margin, p.Y*plotSize+margin
+		fill(img, x, y, x+plotSize, y+plotSize, regionColor(id))
+	}
+	for _, s := range g.sides() {
+		from, to := s.line()
+		// a line is a rectangle centred on it, fenceWidth across, and
+		// running half that further at each end so the corners meet
+		x0, y0 := from.X*plotSize+margin-fenceWidth/2, from.Y*plotSize+margin-fenceWidth/2
+		x1, y1 := to.X*plotSize+margin+fenceWidth/2, to.Y*plotSize+margin+fenceWidth/2
+		fill(img, x0, y0, x1, y1, fenceColor)
+	}
+	if labels {
+		for _, r := range g.regions {
+			drawText(img, r.start.X*plotSize+margin+fenceWidth, r.start.Y*plotSize+margin+fenceWidth, r.label(), labelColor)
+		}
+	}
+	return png.Encode(w, img)
+}
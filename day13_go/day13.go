@@ -0,0 +1,266 @@
+package day13
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/kentquirk/aoc2024/aoc"
+)
+
+const (
+	costForA = 3
+	costForB = 1
+)
+
+// prizeOffset is how much further away the prizes really are in part 2
+const prizeOffset = 10_000_000_000_000
+
+// The reasons a machine can't be won
+var (
+	errOffLine    = errors.New("the buttons only move along a line that misses the prize")
+	errNotWhole   = errors.New("it would take a fraction of a press")
+	errBackwards  = errors.New("it would take a negative number of presses")
+	errTooMany    = errors.New("it would take more presses than allowed")
+	errNoCheapest = errors.New("pressing more keeps making it cheaper")
+)
+
+type vector struct {
+	x, y *big.Int
+}
+
+func (v vector) isZero() bool {
+	return v.x.Sign() == 0 && v.y.Sign() == 0
+}
+
+// cross is the cross product of u and v, which is zero if they point along
+// the same line
+func cross(u, v vector) *big.Int {
+	a := new(big.Int).Mul(u.x, v.y)
+	return a.Sub(a, new(big.Int).Mul(u.y, v.x))
+}
+
+type machine struct {
+	a, b  vector // how far each button moves the claw
+	prize vector
+}
+
+// pricing is what the buttons cost, and how many times each one can be
+// pressed at most. A limit below 0 means there isn't one.
+type pricing struct {
+	costA, costB int64
+	limit        int64
+}
+
+// presses is how many times each button is pressed
+type presses struct {
+	a, b *big.Int
+}
+
+func (pr pricing) cost(p presses) *big.Int {
+	c := new(big.Int).Mul(big.NewInt(pr.costA), p.a)
+	return c.Add(c, new(big.Int).Mul(big.NewInt(pr.costB), p.b))
+}
+
+// solve finds the cheapest presses that land the claw on the prize, or
+// says why there aren't any. Everything is done exactly, however far away
+// the prize is.
+func (m machine) solve(pr pricing) (presses, error) {
+	det := cross(m.a, m.b)
+	if det.Sign() == 0 {
+		return m.solveInLine(pr)
+	}
+	// Cramer's rule: with the buttons pointing different ways there's only
+	// one way to get to the prize, if it's a whole number of presses
+	a := new(big.Rat).SetFrac(cross(m.prize, m.b), det)
+	b := new(big.Rat).SetFrac(cross(m.a, m.prize), det)
+	if !a.IsInt() || !b.IsInt() {
+		return presses{}, errNotWhole
+	}
+	p := presses{new(big.Int).Set(a.Num()), new(big.Int).Set(b.Num())}
+	switch {
+	case p.a.Sign() < 0 || p.b.Sign() < 0:
+		return presses{}, errBackwards
+	case pr.limit >= 0 && (p.a.Cmp(big.NewInt(pr.limit)) > 0 || p.b.Cmp(big.NewInt(pr.limit)) > 0):
+		return presses{}, errTooMany
+	}
+	return p, nil
+}
+
+// solveInLine handles buttons that move the claw along the same line, so
+// there can be lots of ways to get to the prize. Measured in steps of the
+// smallest whole move along the line, the buttons move it alpha and beta
+// and the prize is pi away, so the presses solve alpha*A + beta*B = pi.
+// Every solution is A0 + t*beta/g, B0 - t*alpha/g for the gcd g, and the
+// cost goes steadily up or down with t, so the cheapest is at one end of
+// the range of t that keeps the presses in bounds.
+func (m machine) solveInLine(pr pricing) (presses, error) {
+	step := m.a
+	if step.isZero() {
+		step = m.b
+	}
+	if step.isZero() {
+		if m.prize.isZero() {
+			return presses{new(big.Int), new(big.Int)}, nil
+		}
+		return presses{}, errOffLine
+	}
+	if cross(step, m.prize).Sign() != 0 {
+		return presses{}, errOffLine
+	}
+	g := new(big.Int).GCD(nil, nil, step.x, step.y)
+	step = vector{new(big.Int).Quo(step.x, g), new(big.Int).Quo(step.y, g)}
+	along := func(v vector) *big.Int {
+		if step.x.Sign() != 0 {
+			return new(big.Int).Quo(v.x, step.x)
+		}
+		return new(big.Int).Quo(v.y, step.y)
+	}
+	alpha, beta, pi := along(m.a), along(m.b), along(m.prize)
+
+	x, y := new(big.Int), new(big.Int)
+	g.GCD(x, y, alpha, beta)
+	q, r := new(big.Int).QuoRem(pi, g, new(big.Int))
+	if r.Sign() != 0 {
+		return presses{}, errNotWhole
+	}
+	a0, b0 := x.Mul(x, q), y.Mul(y, q)
+	stepA := new(big.Int).Quo(beta, g)
+	stepB := new(big.Int).Quo(alpha, g)
+	stepB.Neg(stepB)
+
+	var t span
+	zero := new(big.Int)
+	if !t.keep(a0, stepA, zero, nil) || !t.keep(b0, stepB, zero, nil) {
+		return presses{}, errBackwards
+	}
+	if pr.limit >= 0 {
+		limit := big.NewInt(pr.limit)
+		if !t.keep(a0, stepA, zero, limit) || !t.keep(b0, stepB, zero, limit) {
+			return presses{}, errTooMany
+		}
+	}
+
+	slope := pr.cost(presses{stepA, stepB})
+	pick := t.lo
+	if slope.Sign() < 0 || (slope.Sign() == 0 && pick == nil) {
+		pick = t.hi
+	}
+	if pick == nil {
+		// only possible if a button has a negative cost
+		return presses{}, errNoCheapest
+	}
+	return presses{
+		new(big.Int).Add(a0, new(big.Int).Mul(stepA, pick)),
+		new(big.Int).Add(b0, new(big.Int).Mul(stepB, pick)),
+	}, nil
+}
+
+// span is a range of whole numbers, where a nil end means it goes on
+// forever that way
+type span struct {
+	lo, hi *big.Int
+}
+
+// keep narrows the span to the t where lo <= x0 + s*t <= hi, with a nil hi
+// meaning no upper bound. It returns false if that leaves nothing.
+func (sp *span) keep(x0, s, lo, hi *big.Int) bool {
+	if s.Sign() == 0 {
+		return x0.Cmp(lo) >= 0 && (hi == nil || x0.Cmp(hi) <= 0)
+	}
+	// (bound - x0) / s for each bound, which swap over when s is negative
+	from := func(bound *big.Int) *big.Int {
+		if bound == nil {
+			return nil
+		}
+		return new(big.Int).Sub(bound, x0)
+	}
+	atLeast, atMost := from(lo), from(hi)
+	if s.Sign() < 0 {
+		atLeast, atMost = atMost, atLeast
+	}
+	if atLeast != nil {
+		if t := ceilDiv(atLeast, s); sp.lo == nil || t.Cmp(sp.lo) > 0 {
+			sp.lo = t
+		}
+	}
+	if atMost != nil {
+		if t := floorDiv(atMost, s); sp.hi == nil || t.Cmp(sp.hi) < 0 {
+			sp.hi = t
+		}
+	}
+	return sp.lo == nil || sp.hi == nil || sp.lo.Cmp(sp.hi) <= 0
+}
+
+func floorDiv(a, b *big.Int) *big.Int {
+	// big.Int's Div rounds so the remainder is never negative, which is
+	// floor when b is positive but one too high when it isn't
+	q, m := new(big.Int).DivMod(a, b, new(big.Int))
+	if b.Sign() < 0 && m.Sign() != 0 {
+		q.Sub(q, big.NewInt(1))
+	}
+	return q
+}
+
+func ceilDiv(a, b *big.Int) *big.Int {
+	q := floorDiv(new(big.Int).Neg(a), b)
+	return q.Neg(q)
+}
+
+// parseMachines reads each machine's block of button and prize lines
+func parseMachines(lines []string) ([]machine, error) {
+	var machines []machine
+	for i, block := range aoc.SplitParagraphs(lines) {
+		if len(block) != 3 {
+			return nil, fmt.Errorf("machine %d: expected 3 lines, got %d", i+1, len(block))
+		}
+		var v [3]vector
+		for j, line := range block {
+			n, err := aoc.Extractor{Strict: true}.BigInts(line)
+			if err != nil {
+				return nil, fmt.Errorf("machine %d: %w", i+1, err)
+			}
+			if len(n) != 2 {
+				return nil, fmt.Errorf("machine %d: expected 2 numbers in %q", i+1, line)
+			}
+			v[j] = vector{n[0], n[1]}
+		}
+		machines = append(machines, machine{a: v[0], b: v[1], prize: v[2]})
+	}
+	return machines, nil
+}
+
+// play adds up the tokens it takes to win every prize that can be won,
+// printing how each machine goes. The prizes are moved offset further
+// away in both directions first.
+func play(pr pricing, offset int64) aoc.Part {
+	return func(in *aoc.Input) (any, error) {
+		machines, err := parseMachines(in.Lines)
+		if err != nil {
+			return nil, err
+		}
+		total := new(big.Int)
+		for i, m := range machines {
+			m.prize = vector{
+				new(big.Int).Add(m.prize.x, big.NewInt(offset)),
+				new(big.Int).Add(m.prize.y, big.NewInt(offset)),
+			}
+			p, err := m.solve(pr)
+			if err != nil {
+				fmt.Printf("machine %d: no prize, %v\n", i+1, err)
+				continue
+			}
+			c := pr.cost(p)
+			fmt.Printf("machine %d: A %v times, B %v times, %v tokens\n", i+1, p.a, p.b, c)
+			total.Add(total, c)
+		}
+		return total, nil
+	}
+}
+
+func init() {
+	aoc.Register(13, aoc.Parts{
+		play(pricing{costA: costForA, costB: costForB, limit: 100}, 0),
+		play(pricing{costA: costForA, costB: costForB, limit: -1}, prizeOffset),
+	})
+}
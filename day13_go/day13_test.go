@@ -0,0 +1,106 @@
+package day13
+
+import (
+	"math/big"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/kentquirk/aoc2024/aoc/aoctest"
+)
+
+func TestAnswers(t *testing.T) {
+	aoctest.Answers(t, 13)
+}
+
+func BenchmarkParts(b *testing.B) {
+	aoctest.Benchmark(b, 13)
+}
+
+func vec(x, y int64) vector {
+	return vector{big.NewInt(x), big.NewInt(y)}
+}
+
+func Test_machine_solve(t *testing.T) {
+	claws := pricing{costA: costForA, costB: costForB, limit: 100}
+	noLimit := pricing{costA: costForA, costB: costForB, limit: -1}
+	tests := []struct {
+		name string
+		m    machine
+		pr   pricing
+		a, b int64
+		err  error
+	}{
+		{"example 1", machine{vec(94, 34), vec(22, 67), vec(8400, 5400)}, claws, 80, 40, nil},
+		{"example 2", machine{vec(26, 66), vec(67, 21), vec(12748, 12176)}, claws, 0, 0, errNotWhole},
+		{"example 3", machine{vec(17, 86), vec(84, 37), vec(7870, 6450)}, claws, 38, 86, nil},
+		{"backwards", machine{vec(1, 0), vec(0, 1), vec(-1, 1)}, claws, 0, 0, errBackwards},
+		{"over the limit", machine{vec(1, 0), vec(0, 1), vec(101, 1)}, claws, 0, 0, errTooMany},
+		{"no limit", machine{vec(1, 0), vec(0, 1), vec(101, 1)}, noLimit, 101, 1, nil},
+		{"in line, B is cheaper", machine{vec(1, 1), vec(2, 2), vec(10, 10)}, claws, 0, 5, nil},
+		{"in line, A is cheaper", machine{vec(1, 1), vec(2, 2), vec(10, 10)}, pricing{1, 3, -1}, 10, 0, nil},
+		{"in line, both needed", machine{vec(2, 2), vec(3, 3), vec(7, 7)}, claws, 2, 1, nil},
+		{"in line, limit forces A", machine{vec(1, 1), vec(2, 2), vec(300, 300)}, claws, 100, 100, nil},
+		{"in line, too far", machine{vec(1, 1), vec(2, 2), vec(301, 301)}, claws, 0, 0, errTooMany},
+		{"in line, odd", machine{vec(2, 2), vec(4, 4), vec(3, 3)}, claws, 0, 0, errNotWhole},
+		{"in line, missed", machine{vec(1, 1), vec(2, 2), vec(1, 2)}, claws, 0, 0, errOffLine},
+		{"in line, opposite ways", machine{vec(-1, -1), vec(2, 2), vec(3, 3)}, noLimit, 1, 2, nil},
+		{"in line, behind", machine{vec(1, 2), vec(3, 6), vec(-2, -4)}, claws, 0, 0, errBackwards},
+		{"in line, vertical", machine{vec(0, 3), vec(0, 2), vec(0, 7)}, claws, 1, 2, nil},
+		{"one button stuck", machine{vec(0, 0), vec(2, 1), vec(6, 3)}, claws, 0, 3, nil},
+		{"both stuck", machine{vec(0, 0), vec(0, 0), vec(0, 0)}, claws, 0, 0, nil},
+		{"both stuck, missed", machine{vec(0, 0), vec(0, 0), vec(1, 0)}, claws, 0, 0, errOffLine},
+		{"negative cost", machine{vec(-1, -1), vec(1, 1), vec(0, 0)}, pricing{-1, 0, -1}, 0, 0, errNoCheapest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := tt.m.solve(tt.pr)
+			if err != tt.err {
+				t.Fatalf("solve() error = %v, want %v", err, tt.err)
+			}
+			if err == nil && (p.a.Int64() != tt.a || p.b.Int64() != tt.b) {
+				t.Errorf("solve() = %v, %v, want %v, %v", p.a, p.b, tt.a, tt.b)
+			}
+		})
+	}
+}
+
+// slowSolve tries every number of presses
+func slowSolve(m machine, pr pricing) (int64, bool) {
+	best, found := int64(0), false
+	for a := int64(0); a <= pr.limit; a++ {
+		for b := int64(0); b <= pr.limit; b++ {
+			x := a*m.a.x.Int64() + b*m.b.x.Int64()
+			y := a*m.a.y.Int64() + b*m.b.y.Int64()
+			if x == m.prize.x.Int64() && y == m.prize.y.Int64() {
+				if c := a*pr.costA + b*pr.costB; !found || c < best {
+					best, found = c, true
+				}
+			}
+		}
+	}
+	return best, found
+}
+
+func Test_machine_solveRandom(t *testing.T) {
+	r := rand.New(rand.NewPCG(13, 13))
+	n := func() int64 { return r.Int64N(9) - 4 }
+	for range 5000 {
+		// buttons in line with each other often enough to matter
+		a := vec(n(), n())
+		k := n()
+		b := vec(k*a.x.Int64(), k*a.y.Int64())
+		if r.IntN(2) == 0 {
+			b = vec(n(), n())
+		}
+		m := machine{a, b, vec(r.Int64N(41)-10, r.Int64N(41)-10)}
+		pr := pricing{costA: r.Int64N(4), costB: r.Int64N(4), limit: r.Int64N(12)}
+		want, ok := slowSolve(m, pr)
+		p, err := m.solve(pr)
+		if (err == nil) != ok {
+			t.Fatalf("%v with %v: solve() error = %v, want a solution %v", m, pr, err, ok)
+		}
+		if err == nil && pr.cost(p).Int64() != want {
+			t.Fatalf("%v with %v: solve() = %v, %v costing %v, want cost %d", m, pr, p.a, p.b, pr.cost(p), want)
+		}
+	}
+}
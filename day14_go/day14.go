@@ -0,0 +1,339 @@
+package day14
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/kentquirk/aoc2024/aoc"
+	"github.com/kentquirk/aoc2024/aoc/geom"
+	"github.com/kentquirk/aoc2024/aoc/grid"
+)
+
+type robot struct {
+	p, v geom.Point
+}
+
+// swarm is the robots on a floor w wide and h high. They wrap around the
+// edges, so each robot's x repeats every w seconds and its y every h.
+type swarm struct {
+	w, h   int
+	robots []robot
+}
+
+// parseSwarm reads the floor size, as in "w=11 h=7", and then a robot on
+// each line
+func parseSwarm(lines []string) (*swarm, error) {
+	x := aoc.Extractor{Strict: true}
+	rows, err := x.IntsInLines(lines)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 || len(rows[0]) != 2 || rows[0][0] <= 0 || rows[0][1] <= 0 {
+		return nil, errors.New("line 1: expected the floor's width and height")
+	}
+	s := &swarm{w: rows[0][0], h: rows[0][1]}
+	for i, row := range rows[1:] {
+		switch len(row) {
+		case 0:
+			continue
+		case 4:
+			s.robots = append(s.robots, robot{geom.Point{X: row[0], Y: row[1]}, geom.Point{X: row[2], Y: row[3]}})
+		default:
+			return nil, fmt.Errorf("line %d: expected 4 numbers, got %d", i+2, len(row))
+		}
+	}
+	return s, nil
+}
+
+// wrap returns a mod n, from 0 to n-1 even when a is negative
+func wrap(a, n int) int {
+	return ((a % n) + n) % n
+}
+
+// xsAt returns every robot's x after t seconds
+func (s *swarm) xsAt(t int) []int {
+	xs := make([]int, len(s.robots))
+	for i, r := range s.robots {
+		xs[i] = wrap(r.p.X+r.v.X*wrap(t, s.w), s.w)
+	}
+	return xs
+}
+
+// ysAt returns every robot's y after t seconds
+func (s *swarm) ysAt(t int) []int {
+	ys := make([]int, len(s.robots))
+	for i, r := range s.robots {
+		ys[i] = wrap(r.p.Y+r.v.Y*wrap(t, s.h), s.h)
+	}
+	return ys
+}
+
+// frame is where every robot is at one moment
+type frame struct {
+	w, h   int
+	xs, ys []int
+}
+
+func (s *swarm) frameAt(t int) frame {
+	return frame{s.w, s.h, s.xsAt(t), s.ysAt(t)}
+}
+
+// safety multiplies together the number of robots in each quadrant,
+// leaving out the ones on the lines down the middle
+func (f frame) safety() int {
+	var quads [4]int
+	for i, x := range f.xs {
+		y := f.ys[i]
+		if x == f.w/2 || y == f.h/2 {
+			continue
+		}
+		q := 0
+		if x > f.w/2 {
+			q += 2
+		}
+		if y > f.h/2 {
+			q++
+		}
+		quads[q]++
+	}
+	return quads[0] * quads[1] * quads[2] * quads[3]
+}
+
+// largestGroup is the number of robots in the biggest group of occupied
+// tiles that touch side by side
+func (f frame) largestGroup() int {
+	g := grid.New[bool](f.w, f.h)
+	for i, x := range f.xs {
+		g.Set(grid.Point{X: x, Y: f.ys[i]}, true)
+	}
+	best := 0
+	for p, occupied := range g.All() {
+		if !occupied {
+			continue
+		}
+		// clear each tile as it's counted, so it's only counted once
+		size := 0
+		g.Set(p, false)
+		queue := []grid.Point{p}
+		for len(queue) > 0 {
+			p, queue = queue[0], queue[1:]
+			size++
+			for q, occupied := range g.Neighbors4(p) {
+				if occupied {
+					g.Set(q, false)
+					queue = append(queue, q)
+				}
+			}
+		}
+		best = max(best, size)
+	}
+	return best
+}
+
+func (f frame) String() string {
+	g := grid.New[bool](f.w, f.h)
+	for i, x := range f.xs {
+		g.Set(grid.Point{X: x, Y: f.ys[i]}, true)
+	}
+	return g.Format(func(_ grid.Point, occupied bool) string {
+		if occupied {
+			return "#"
+		}
+		return "."
+	})
+}
+
+// variance is how spread out the coordinates are
+func variance(coords []int) float64 {
+	sum, squares := 0, 0
+	for _, c := range coords {
+		sum += c
+		squares += c * c
+	}
+	n := float64(len(coords))
+	return (n*float64(squares) - float64(sum)*float64(sum)) / (n * n)
+}
+
+// entropy is how evenly the coordinates are spread over the size rows or
+// columns, in bits. It only depends on how many rows have each number of
+// robots, and adds them up in that order, so frames that are the same but
+// for which row is which always come out exactly the same.
+func entropy(coords []int, size int) float64 {
+	counts := make([]int, size)
+	for _, c := range coords {
+		counts[c]++
+	}
+	rows := make([]int, len(coords)+1) // the number of rows with k robots
+	for _, k := range counts {
+		rows[k]++
+	}
+	n := float64(len(coords))
+	e := 0.0
+	for k, r := range rows {
+		if k > 0 && r > 0 {
+			p := float64(k) / n
+			e -= float64(r) * p * math.Log2(p)
+		}
+	}
+	return e
+}
+
+// metric scores how much the robots are clumped together in a frame, with
+// a higher score for more of a clump. Metrics that are just a score for the
+// x's plus one for the y's have an axis function, which lets the x's and
+// y's be searched separately.
+type metric struct {
+	name  string
+	axis  func(coords []int, size int) float64
+	frame func(f frame) float64
+}
+
+func (m metric) score(f frame) float64 {
+	if m.axis != nil {
+		return m.axis(f.xs, f.w) + m.axis(f.ys, f.h)
+	}
+	return m.frame(f)
+}
+
+var metrics = []metric{
+	{name: "variance", axis: func(coords []int, _ int) float64 { return -variance(coords) }},
+	{name: "entropy", axis: func(coords []int, size int) float64 { return -entropy(coords, size) }},
+	{name: "safety", frame: func(f frame) float64 { return -float64(f.safety()) }},
+	{name: "group", frame: func(f frame) float64 { return float64(f.largestGroup()) }},
+}
+
+func lookupMetric(name string) (metric, error) {
+	for _, m := range metrics {
+		if m.name == name {
+			return m, nil
+		}
+	}
+	return metric{}, fmt.Errorf("unknown metric %q", name)
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// crt finds the first time t with t mod m == a and t mod n == b, by the
+// Chinese remainder theorem. It returns false if there isn't one, which
+// can only happen if m and n have a common factor.
+func crt(a, m, b, n int) (int, bool) {
+	// find u with u*m == g mod n, by the extended Euclidean algorithm
+	g, u := m, 1
+	for r, v := n, 0; r != 0; {
+		q := g / r
+		g, r = r, g-q*r
+		u, v = v, u-q*v
+	}
+	if (b-a)%g != 0 {
+		return 0, false
+	}
+	lcm := m / g * n
+	k := wrap((b-a)/g*u, n/g)
+	return wrap(a+k*m, lcm), true
+}
+
+// bestTimes returns the times from 0 to period-1 with the highest score
+func bestTimes(period int, score func(t int) float64) []int {
+	var best []int
+	top := math.Inf(-1)
+	for t := range period {
+		switch s := score(t); {
+		case s > top:
+			top, best = s, []int{t}
+		case s == top:
+			best = append(best, t)
+		}
+	}
+	return best
+}
+
+// search finds the first time the robots are most clumped together by the
+// metric. Everything repeats after lcm(w, h) seconds, so that's as far as
+// it needs to look. A metric with an axis score only needs the best x times
+// out of the first w and y times out of the first h, and the Chinese
+// remainder theorem puts them together. Otherwise every frame gets scored,
+// but they're made from a table of the w different x's and h different y's
+// rather than by moving the robots each time.
+func (s *swarm) search(m metric) int {
+	if len(s.robots) == 0 {
+		return 0
+	}
+	if m.axis != nil {
+		xTimes := bestTimes(s.w, func(t int) float64 { return m.axis(s.xsAt(t), s.w) })
+		yTimes := bestTimes(s.h, func(t int) float64 { return m.axis(s.ysAt(t), s.h) })
+		first := -1
+		for _, tx := range xTimes {
+			for _, ty := range yTimes {
+				if t, ok := crt(tx, s.w, ty, s.h); ok && (first < 0 || t < first) {
+					first = t
+				}
+			}
+		}
+		if first >= 0 {
+			return first
+		}
+		// the best x's and y's never happen at once, so fall back on
+		// scoring whole frames
+	}
+
+	xs := make([][]int, s.w)
+	for t := range xs {
+		xs[t] = s.xsAt(t)
+	}
+	ys := make([][]int, s.h)
+	for t := range ys {
+		ys[t] = s.ysAt(t)
+	}
+	period := s.w / gcd(s.w, s.h) * s.h
+	return bestTimes(period, func(t int) float64 {
+		return m.score(frame{s.w, s.h, xs[t%s.w], ys[t%s.h]})
+	})[0]
+}
+
+func part1(lines []string) (int, error) {
+	s, err := parseSwarm(lines)
+	if err != nil {
+		return 0, err
+	}
+	return s.frameAt(100).safety(), nil
+}
+
+// part2 finds when the robots make a picture, by finding when they're most
+// clumped together. The metric is variance unless the arguments name
+// another, as in "aoc run 14 group". With "show", as in
+// "aoc run -v 14 show", it prints the picture.
+func part2(in *aoc.Input) (any, error) {
+	m := metrics[0]
+	show := false
+	for _, arg := range in.Args {
+		if arg == "show" {
+			show = true
+			continue
+		}
+		var err error
+		if m, err = lookupMetric(arg); err != nil {
+			return nil, err
+		}
+	}
+	s, err := parseSwarm(in.Lines)
+	if err != nil {
+		return nil, err
+	}
+	t := s.search(m)
+	if show {
+		f := s.frameAt(t)
+		fmt.Printf("after %d seconds, %s %g:\n", t, m.name, m.score(f))
+		fmt.Print(f)
+	}
+	return t, nil
+}
+
+func init() {
+	aoc.Register(14, aoc.Parts{aoc.OnLinesErr(part1), part2})
+}
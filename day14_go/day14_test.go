@@ -0,0 +1,85 @@
+package day14
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/kentquirk/aoc2024/aoc/aoctest"
+	"github.com/kentquirk/aoc2024/aoc/geom"
+)
+
+func TestAnswers(t *testing.T) {
+	aoctest.Answers(t, 14)
+}
+
+func BenchmarkParts(b *testing.B) {
+	aoctest.Benchmark(b, 14)
+}
+
+func Test_crt(t *testing.T) {
+	tests := []struct {
+		a, m, b, n int
+		want       int
+		ok         bool
+	}{
+		{2, 3, 3, 5, 8, true},
+		{0, 101, 0, 103, 0, true},
+		{7037 % 101, 101, 7037 % 103, 103, 7037, true},
+		{1, 4, 3, 6, 9, true},
+		{1, 4, 2, 6, 0, false},
+		{0, 1, 4, 7, 4, true},
+	}
+	for _, tt := range tests {
+		got, ok := crt(tt.a, tt.m, tt.b, tt.n)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("crt(%d, %d, %d, %d) = %d, %v, want %d, %v", tt.a, tt.m, tt.b, tt.n, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func Test_frame(t *testing.T) {
+	s, err := parseSwarm([]string{"w=11 h=7", "p=2,4 v=2,-3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the robot's moves from the puzzle
+	for i, want := range []string{"2,4", "4,1", "6,5", "8,2", "10,6", "1,3"} {
+		f := s.frameAt(i)
+		if got := fmt.Sprintf("%d,%d", f.xs[0], f.ys[0]); got != want {
+			t.Errorf("after %d seconds the robot is at %s, want %s", i, got, want)
+		}
+	}
+	f := frame{w: 5, h: 5, xs: []int{0, 1, 1, 3, 4, 4, 2}, ys: []int{0, 0, 1, 4, 4, 0, 2}}
+	if got := f.safety(); got != 0 {
+		t.Errorf("safety() = %d, want 0", got)
+	}
+	if got := f.largestGroup(); got != 3 {
+		t.Errorf("largestGroup() = %d, want 3", got)
+	}
+	if got, want := f.String(), "##..#\n.#...\n..#..\n.....\n...##\n"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+// Searching with the Chinese remainder theorem should find the same time
+// as scoring every frame
+func Test_swarm_search(t *testing.T) {
+	r := rand.New(rand.NewPCG(14, 14))
+	for range 200 {
+		s := &swarm{w: 2 + r.IntN(8), h: 2 + r.IntN(8)}
+		for range 1 + r.IntN(6) {
+			s.robots = append(s.robots, robot{
+				geom.Point{X: r.IntN(s.w), Y: r.IntN(s.h)},
+				geom.Point{X: r.IntN(11) - 5, Y: r.IntN(11) - 5},
+			})
+		}
+		period := s.w / gcd(s.w, s.h) * s.h
+		for _, m := range metrics {
+			want := bestTimes(period, func(t int) float64 { return m.score(s.frameAt(t)) })[0]
+			if got := s.search(m); got != want {
+				t.Fatalf("%+v: search(%s) = %d, want %d", s, m.name, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package day16
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/kentquirk/aoc2024/aoc"
+	"github.com/kentquirk/aoc2024/aoc/geom"
+	"github.com/kentquirk/aoc2024/aoc/search"
+)
+
+type direction = geom.Dir4
+
+const (
+	north = geom.Up
+	south = geom.Down
+	east  = geom.Right
+	west  = geom.Left
+)
+
+type point = geom.Point
+
+type node struct {
+	point
+	neighbors map[direction]point
+}
+
+type maze struct {
+	w        int
+	h        int
+	walls    map[point]struct{}
+	deadends map[point]struct{}
+	m        map[point]*node
+	s        point
+	e        point
+}
+
+func (m maze) Print(path []point) {
+	for r := 0; r < m.h; r++ {
+		for c := 0; c < m.w; c++ {
+			p := point{X: c, Y: r}
+			if _, ok := m.walls[p]; ok {
+				fmt.Print("#")
+			} else if p == m.s {
+				fmt.Print("S")
+			} else if p == m.e {
+				fmt.Print("E")
+			} else if path != nil && slices.Contains(path, p) {
+				fmt.Print("o")
+			} else if _, ok := m.deadends[p]; ok {
+				fmt.Print("!")
+			} else {
+				fmt.Print(".")
+			}
+		}
+		fmt.Println()
+	}
+}
+
+func parseMap(lines []string) maze {
+	maze := maze{
+		w:        len(lines[0]),
+		h:        len(lines),
+		walls:    make(map[point]struct{}),
+		deadends: make(map[point]struct{}),
+		m:        make(map[point]*node),
+	}
+	for r, line := range lines {
+		for c, char := range line {
+			if char == '#' {
+				maze.walls[point{X: c, Y: r}] = struct{}{}
+				continue
+			}
+			p := point{X: c, Y: r}
+			n := &node{point: p, neighbors: make(map[direction]point)}
+			maze.m[p] = n
+			if char == 'S' {
+				maze.s = p
+			}
+			if char == 'E' {
+				maze.e = p
+			}
+		}
+	}
+	for p, n := range maze.m {
+		if _, ok := maze.m[p.Move(north)]; ok {
+			n.neighbors[north] = p.Move(north)
+		}
+		if _, ok := maze.m[p.Move(south)]; ok {
+			n.neighbors[south] = p.Move(south)
+		}
+		if _, ok := maze.m[p.Move(east)]; ok {
+			n.neighbors[east] = p.Move(east)
+		}
+		if _, ok := maze.m[p.Move(west)]; ok {
+			n.neighbors[west] = p.Move(west)
+		}
+	}
+	return maze
+}
+
+func cp(path []point, p point) []point {
+	q := make([]point, len(path)+1)
+	copy(q, path)
+	q[len(path)] = p
+	return q
+}
+
+// a reindeer is where it is and which way it's facing; turning in place
+// costs as much as a thousand steps
+type reindeer struct {
+	p point
+	d direction
+}
+
+func (m *maze) moves(r reindeer) []search.Edge[reindeer] {
+	edges := []search.Edge[reindeer]{
+		{To: reindeer{r.p, r.d.TurnLeft()}, Cost: 1000},
+		{To: reindeer{r.p, r.d.TurnRight()}, Cost: 1000},
+	}
+	if next, ok := m.m[r.p].neighbors[r.d]; ok {
+		edges = append(edges, search.Edge[reindeer]{To: reindeer{next, r.d}, Cost: 1})
+	}
+	return edges
+}
+
+// findPaths finds the cheapest way to the end from the start (facing east).
+// It returns the search result, the end states that are that cheap, and the
+// cost, which is -1 if the end can't be reached.
+func (m *maze) findPaths() (*search.Result[reindeer], []reindeer, int) {
+	r := search.Dijkstra(m.moves, reindeer{m.s, east})
+	best := -1
+	var ends []reindeer
+	for _, d := range []direction{north, south, east, west} {
+		end := reindeer{m.e, d}
+		cost, ok := r.Reached(end)
+		switch {
+		case !ok:
+		case best < 0 || cost < best:
+			best, ends = cost, []reindeer{end}
+		case cost == best:
+			ends = append(ends, end)
+		}
+	}
+	return r, ends, best
+}
+
+func (m *maze) markDeadends() {
+	passes := 0
+	for found := true; found; {
+		passes++
+		fmt.Println("pass", passes)
+		found = false
+		for p, n := range m.m {
+			if _, ok := m.deadends[p]; ok {
+				continue
+			}
+			if len(n.neighbors) == 1 && p != m.s && p != m.e {
+				m.deadends[p] = struct{}{}
+				// there's only one of these
+				for dir, neighbor := range n.neighbors {
+					toMe := dir.Opposite()
+					delete(m.m[neighbor].neighbors, toMe)
+				}
+				found = true
+			}
+		}
+	}
+}
+
+// bestTiles returns every tile on any of the cheapest paths
+func (m *maze) bestTiles() []point {
+	r, ends, _ := m.findPaths()
+	var tiles []point
+	for s := range r.OnShortestPaths(ends...) {
+		if !slices.Contains(tiles, s.p) {
+			tiles = append(tiles, s.p)
+		}
+	}
+	return tiles
+}
+
+func part1(lines []string) int {
+	m := parseMap(lines)
+	m.Print(nil)
+	m.markDeadends()
+	m.Print(nil)
+	r, ends, cost := m.findPaths()
+	if cost >= 0 {
+		var path []point
+		for _, s := range r.Path(ends[0]) {
+			path = cp(path, s.p)
+		}
+		m.Print(path)
+	}
+	return cost
+}
+
+func part2(lines []string) int {
+	m := parseMap(lines)
+	m.markDeadends()
+	tiles := m.bestTiles()
+	m.Print(tiles)
+	return len(tiles)
+}
+
+func init() {
+	aoc.Register(16, aoc.Parts{aoc.OnLines(part1), aoc.OnLines(part2)})
+}
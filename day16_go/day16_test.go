@@ -1,8 +1,10 @@
-package main
+package day16
 
 import (
 	"reflect"
 	"testing"
+
+	"github.com/kentquirk/aoc2024/aoc/aoctest"
 )
 
 func Test_cp(t *testing.T) {
@@ -12,7 +14,7 @@ func Test_cp(t *testing.T) {
 		p    point
 		want []point
 	}{
-		{"a", []point{{0, 0}}, point{1, 1}, []point{{0, 0}, {1, 1}}},
+		{"a", []point{{X: 0, Y: 0}}, point{X: 1, Y: 1}, []point{{X: 0, Y: 0}, {X: 1, Y: 1}}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -22,3 +24,11 @@ func Test_cp(t *testing.T) {
 		})
 	}
 }
+
+func TestAnswers(t *testing.T) {
+	aoctest.Answers(t, 16)
+}
+
+func BenchmarkParts(b *testing.B) {
+	aoctest.Benchmark(b, 16)
+}
@@ -1,14 +1,10 @@
-package main
+package day17
 
 import (
 	"fmt"
-	"io"
-	"log"
-	"math/rand"
-	"os"
-	"regexp"
-	"strconv"
 	"strings"
+
+	"github.com/kentquirk/aoc2024/aoc"
 )
 
 type opcode byte
@@ -175,16 +171,6 @@ func (v *vm) Quineiness() int {
 	return identical
 }
 
-func parseNumbersFrom(line string) []int {
-	pat := regexp.MustCompile(`\d+`)
-	parts := pat.FindAllString(line, -1)
-	numbers := make([]int, len(parts))
-	for i, part := range parts {
-		numbers[i], _ = strconv.Atoi(part)
-	}
-	return numbers
-}
-
 func loadProgram(lines []string) *vm {
 	vm := &vm{
 		registers: make(map[string]int),
@@ -192,13 +178,14 @@ func loadProgram(lines []string) *vm {
 	}
 	for _, line := range lines {
 		if strings.HasPrefix(line, "Register") {
-			pat := regexp.MustCompile(`Register (\w+): (\d+)`)
-			matches := pat.FindStringSubmatch(line)
-			vm.registers[matches[1]], _ = strconv.Atoi(matches[2])
+			name, value, _ := strings.Cut(strings.TrimPrefix(line, "Register "), ":")
+			if numbers := aoc.Ints(value); len(numbers) > 0 {
+				vm.registers[name] = numbers[0]
+			}
 			continue
 		}
 		if strings.HasPrefix(line, "Program") {
-			code := parseNumbersFrom(line)
+			code := aoc.Ints(line)
 			for i := 0; i < len(code); i++ {
 				vm.code = append(vm.code, byte(code[i]))
 			}
@@ -207,7 +194,7 @@ func loadProgram(lines []string) *vm {
 	return vm
 }
 
-func part1(lines []string) int {
+func part1(lines []string) string {
 	vm := loadProgram(lines)
 	vm.Print()
 	for vm.Step() {
@@ -219,7 +206,7 @@ func part1(lines []string) int {
 		outputs = append(outputs, fmt.Sprintf("%d", value))
 	}
 	fmt.Println(strings.Join(outputs, ","))
-	return 0
+	return strings.Join(outputs, ",")
 }
 
 // This is ugly. The VM is fine, worked, runs fast, but clearly the problem
@@ -244,32 +231,6 @@ func part2(lines []string) int {
 	registers["B"] = vm.registers["B"]
 	registers["C"] = vm.registers["C"]
 
-	bot := 0o0777777777777777
-	top := 0o7777777777777777
-	// bot := 0o3477556042247155
-	// top := 0o3477556062247277
-
-	// best := 0
-	// for i := bot; i < top; i++ {
-	// 	vm.RunWith(registers, i)
-	// 	quineiness := vm.Quineiness()
-	// 	if quineiness > best {
-	// 		best = quineiness
-	// 		fmt.Printf("%o, %d\n", i, best)
-	// 	}
-	// 	if vm.Quine() {
-	// 		fmt.Printf("%o\n", i)
-	// 		break
-	// 	}
-	// }
-	// os.Exit(0)
-
-	ntests := 500000
-	endings := []int{
-		0o56052247155,
-		0o56052247277,
-	}
-
 	// this was a valid quine but not the lowest one
 	// so we keep the lower bits and search for the upper bits
 	// the correct value was 0o6117156052247277
@@ -281,76 +242,16 @@ func part2(lines []string) int {
 		if vm.Quine() {
 			fmt.Printf("found it 0o%o, %d\n", test, test)
 			vm.Print()
-			os.Exit(0)
+			return test
 		}
 		quineiness := vm.Quineiness()
 		if quineiness > 14 {
 			fmt.Printf("%d\n", quineiness)
 		}
 	}
-	os.Exit(0)
-
-	for {
-		var best1, best2 int
-		var q1, q2 int
-		spread := top - bot
-		for i := 0; i < ntests; i++ {
-			test := bot + rand.Intn(spread)
-			test &= ^0o3777777777
-			test |= endings[rand.Intn(len(endings))]
-			vm.RunWith(registers, test)
-			quineiness := vm.Quineiness()
-			if quineiness > best1 {
-				best2 = best1
-				q2 = q1
-				best1 = quineiness
-				q1 = test
-			} else if quineiness > best2 {
-				best2 = quineiness
-				q2 = test
-			}
-			if quineiness >= 11 {
-				fmt.Printf("%d %o\n", quineiness, test)
-			}
-			// 127523764129389
-			// 127523764129471
-			// if quineiness > 3 {
-			// 	fmt.Println(strings.Repeat("=", quineiness))
-			// }
-		}
-		// fmt.Printf("%o (%d), %o(%d)\n", q1, best1, q2, best2)
-		if q2 < q1 {
-			q1, q2 = q2, q1
-		}
-		bot = q1
-		top = q2
-		fmt.Println("now ", bot, top)
-		if best1 == 16 {
-			break
-		}
-	}
 	return 0
 }
 
-func readlines(filename string) []string {
-	f, err := os.Open(fmt.Sprintf("./data/%s.txt", filename))
-	if err != nil {
-		log.Fatal(err)
-	}
-	b, err := io.ReadAll(f)
-	if err != nil {
-		log.Fatal(err)
-	}
-	return strings.Split(string(b), "\n")
-}
-
-func main() {
-	args := os.Args[1:]
-	filename := "sample"
-	if len(args) > 0 {
-		filename = args[0]
-	}
-	lines := readlines(filename)
-	fmt.Println(part1(lines))
-	fmt.Println(part2(lines))
+func init() {
+	aoc.Register(17, aoc.Parts{aoc.OnLines(part1), aoc.OnLines(part2)})
 }
@@ -0,0 +1,203 @@
+package day18
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/kentquirk/aoc2024/aoc"
+	"github.com/kentquirk/aoc2024/aoc/geom"
+	"github.com/kentquirk/aoc2024/aoc/search"
+)
+
+type point = geom.Point
+
+type memory struct {
+	w       int
+	h       int
+	pairs   []point
+	open    map[point]int
+	blocked map[point]int
+}
+
+func newMemory(w, h int) *memory {
+	m := &memory{w: w, h: h, pairs: make([]point, 0), open: make(map[point]int), blocked: make(map[point]int)}
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			// -1 means open and not part of the path
+			// a nonnegative integer indicates which step on the path
+			m.open[point{X: x, Y: y}] = -1
+		}
+	}
+	return m
+}
+
+func (m *memory) block(p point, t int) bool {
+	if m.open[p] == -1 {
+		m.blocked[p] = t
+		delete(m.open, p)
+		return true
+	}
+	m.Print(p)
+	fmt.Println(m.open)
+	panic(fmt.Sprintf("cannot block point %v at time %d", p, t))
+}
+
+func (m *memory) addToPath(p point, t int) bool {
+	if m.open[p] == -1 {
+		m.open[p] = t
+		return true
+	}
+	return false
+}
+
+func (m *memory) resetPath() {
+	for k, v := range m.open {
+		if v >= 0 {
+			m.open[k] = -1
+		}
+	}
+}
+
+func (m *memory) neighbors(p point) []search.Edge[point] {
+	edges := make([]search.Edge[point], 0, 4)
+	for _, d := range geom.Dirs4 {
+		if _, ok := m.open[p.Move(d)]; ok {
+			edges = append(edges, search.Edge[point]{To: p.Move(d), Cost: 1})
+		}
+	}
+	return edges
+}
+
+func (m *memory) findPath() int {
+	end := point{X: m.w - 1, Y: m.h - 1}
+	path, distance, found := search.AStar(point{}, func(p point) bool { return p == end }, m.neighbors,
+		func(p point) int { return p.Manhattan(end) })
+	for i, p := range path {
+		m.addToPath(p, i)
+	}
+	if found {
+		return distance
+	}
+	return -1
+}
+
+func (m memory) Print(mark point) {
+	for y := 0; y < m.h; y++ {
+		for x := 0; x < m.w; x++ {
+			pt := point{X: x, Y: y}
+			if pt == mark {
+				fmt.Print("!")
+			} else if _, ok := m.blocked[pt]; ok {
+				fmt.Print("#")
+			} else if i, ok := m.open[pt]; ok && i >= 0 {
+				fmt.Print("o")
+			} else {
+				fmt.Print(".")
+			}
+		}
+		fmt.Println()
+	}
+}
+
+func part1(lines []string, size int, maxTime int) int {
+	m := newMemory(size, size)
+	for _, line := range lines {
+		numbers := aoc.Ints(line)
+		pt := point{X: numbers[0], Y: numbers[1]}
+		m.pairs = append(m.pairs, pt)
+	}
+	for i, pt := range m.pairs {
+		if i >= maxTime {
+			break
+		}
+		m.block(pt, i)
+	}
+	d := m.findPath()
+	// m.Print()
+	return d
+}
+
+func part2BS(lines []string, size int) string {
+	// binary search for the lowest value of maxTime that returns -1 when calling part1
+	minValue := 1024
+	maxValue := len(lines)
+	for minValue < maxValue {
+		midValue := (minValue + maxValue) / 2
+		if part1(lines, size, midValue) == -1 {
+			maxValue = midValue
+		} else {
+			minValue = midValue + 1
+		}
+	}
+	return lines[minValue]
+}
+
+// part2 drops the bytes one at a time until one cuts off the exit. It
+// checks stopped before each one, and gives up if it says to.
+func part2(lines []string, size int, stopped func() bool) (point, int, error) {
+	m := newMemory(size, size)
+	for _, line := range lines {
+		numbers := aoc.Ints(line)
+		pt := point{X: numbers[0], Y: numbers[1]}
+		m.pairs = append(m.pairs, pt)
+	}
+	for i, pt := range m.pairs {
+		if stopped() {
+			return point{}, 0, aoc.ErrStopped
+		}
+		m.resetPath()
+		m.block(pt, i)
+		if d := m.findPath(); d == -1 {
+			m.Print(pt)
+			return pt, i, nil
+		}
+	}
+	m.Print(point{X: -1, Y: -1})
+	fmt.Println("No solution found", len(m.pairs), len(lines), len(m.open), len(m.blocked))
+	return point{X: -1, Y: -1}, -1, nil
+}
+
+// memoryParams returns the memory size and the number of bytes to drop for
+// part1. They're the real input's unless the arguments give the max index
+// and time, as the sample needs with "aoc run 18 --input sample 6 12".
+func memoryParams(in *aoc.Input) (int, int, error) {
+	maxIndex, maxTime := 70, 1024
+	var err error
+	if len(in.Args) > 0 {
+		if maxIndex, err = strconv.Atoi(in.Args[0]); err != nil {
+			return 0, 0, err
+		}
+	}
+	if len(in.Args) > 1 {
+		if maxTime, err = strconv.Atoi(in.Args[1]); err != nil {
+			return 0, 0, err
+		}
+	}
+	return maxIndex + 1, maxTime, nil // add 1 to account for 0-based index
+}
+
+func init() {
+	aoc.Register(18, aoc.Parts{
+		func(in *aoc.Input) (any, error) {
+			size, maxTime, err := memoryParams(in)
+			if err != nil {
+				return nil, err
+			}
+			return part1(in.Lines, size, maxTime), nil
+		},
+		func(in *aoc.Input) (any, error) {
+			size, _, err := memoryParams(in)
+			if err != nil {
+				return nil, err
+			}
+			pt, _, err := part2(in.Lines, size, in.Stopped)
+			if err != nil {
+				return nil, err
+			}
+			if pt.X < 0 {
+				return nil, fmt.Errorf("no byte blocks the exit")
+			}
+			return fmt.Sprintf("%d,%d", pt.X, pt.Y), nil
+		},
+	})
+}
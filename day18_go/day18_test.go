@@ -0,0 +1,43 @@
+package day18
+
+import (
+	"testing"
+
+	"github.com/kentquirk/aoc2024/aoc"
+	"github.com/kentquirk/aoc2024/aoc/aoctest"
+)
+
+func TestAnswers(t *testing.T) {
+	aoctest.Answers(t, 18)
+}
+
+func BenchmarkParts(b *testing.B) {
+	aoctest.Benchmark(b, 18)
+}
+
+func Test_memoryParams(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       aoc.Input
+		wantSize int
+		wantTime int
+		wantErr  bool
+	}{
+		{"defaults", aoc.Input{Name: "input"}, 71, 1024, false},
+		{"name doesn't matter", aoc.Input{Name: "sample"}, 71, 1024, false},
+		{"size", aoc.Input{Args: []string{"6"}}, 7, 1024, false},
+		{"size and time", aoc.Input{Args: []string{"6", "12"}}, 7, 12, false},
+		{"bad size", aoc.Input{Args: []string{"x"}}, 0, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			size, maxTime, err := memoryParams(&tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("memoryParams() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if size != tt.wantSize || maxTime != tt.wantTime {
+				t.Errorf("memoryParams() = %d, %d, want %d, %d", size, maxTime, tt.wantSize, tt.wantTime)
+			}
+		})
+	}
+}
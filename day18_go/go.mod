@@ -2,4 +2,6 @@ module github.com/kentquirk/aoc2024/day18
 
 go 1.23
 
-require github.com/beefsack/go-astar v0.0.0-20200827232313-4ecf9e304482 // indirect
+require github.com/kentquirk/aoc2024 v0.0.0
+
+replace github.com/kentquirk/aoc2024 => ../
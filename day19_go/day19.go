@@ -1,12 +1,11 @@
-package main
+package day19
 
 import (
 	"fmt"
-	"io"
-	"log"
-	"os"
 	"slices"
 	"strings"
+
+	"github.com/kentquirk/aoc2024/aoc"
 )
 
 func parse(lines []string) ([]string, []string) {
@@ -34,6 +33,7 @@ func countFromRight(requirement string, towels []string, level int) int {
 	return 0
 }
 
+// cache is keyed only by requirement, so it's only good for one set of towels
 var cache map[string]int = make(map[string]int)
 
 func combosFromRight(requirement string, towels []string, level int) int {
@@ -80,6 +80,7 @@ func part1(lines []string) int {
 
 func part2(lines []string) int {
 	towels, requirements := parse(lines)
+	cache = make(map[string]int)
 	total := 0
 	for _, r := range requirements {
 		combos := combosFromRight(r, towels, 1)
@@ -93,25 +94,6 @@ func part2(lines []string) int {
 	return total
 }
 
-func readlines(filename string) []string {
-	f, err := os.Open(fmt.Sprintf("./data/%s.txt", filename))
-	if err != nil {
-		log.Fatal(err)
-	}
-	b, err := io.ReadAll(f)
-	if err != nil {
-		log.Fatal(err)
-	}
-	return strings.Split(string(b), "\n")
-}
-
-func main() {
-	args := os.Args[1:]
-	filename := "sample"
-	if len(args) > 0 {
-		filename = args[0]
-	}
-	lines := readlines(filename)
-	fmt.Println(part1(lines))
-	fmt.Println(part2(lines))
+func init() {
+	aoc.Register(19, aoc.Parts{aoc.OnLines(part1), aoc.OnLines(part2)})
 }
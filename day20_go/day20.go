@@ -1,74 +1,24 @@
-package main
+package day20
 
 import (
 	"fmt"
-	"io"
-	"log"
-	"os"
 	"slices"
-	"strings"
 
-	"github.com/beefsack/go-astar"
+	"github.com/kentquirk/aoc2024/aoc"
+	"github.com/kentquirk/aoc2024/aoc/geom"
+	"github.com/kentquirk/aoc2024/aoc/search"
 )
 
-func abs(x int) int {
-	if x < 0 {
-		return -x
-	}
-	return x
-}
-
-type direction byte
+type direction = geom.Dir4
 
 const (
-	north direction = iota
-	east
-	south
-	west
+	north = geom.Up
+	east  = geom.Right
+	south = geom.Down
+	west  = geom.Left
 )
 
-func (d direction) String() string {
-	return string(d)
-}
-
-func (d direction) left() direction {
-	return (d + 3) % 4
-}
-
-func (d direction) right() direction {
-	return (d + 1) % 4
-}
-
-func (d direction) opposite() direction {
-	return (d + 2) % 4
-}
-
-var directionDeltas = map[direction]point{
-	north: {r: -1, c: 0},
-	east:  {r: 0, c: 1},
-	south: {r: 1, c: 0},
-	west:  {r: 0, c: -1},
-}
-
-type point struct {
-	r, c int
-}
-
-func (p point) add(q point) point {
-	return point{p.r + q.r, p.c + q.c}
-}
-
-func (p point) next(d direction) point {
-	return p.add(directionDeltas[d])
-}
-
-func (p point) tunnel(d direction) point {
-	return p.add(directionDeltas[d]).add(directionDeltas[d])
-}
-
-func (p point) String() string {
-	return fmt.Sprintf("(%d, %d)", p.r, p.c)
-}
+type point = geom.Point
 
 type node struct {
 	p         point
@@ -77,24 +27,12 @@ type node struct {
 	tunnels   []*node
 }
 
-// ensure that node implements astar.Pather
-var _ astar.Pather = &node{}
-
-func (n *node) PathNeighbors() []astar.Pather {
-	neighbors := make([]astar.Pather, len(n.neighbors))
+func (n *node) steps() []search.Edge[*node] {
+	edges := make([]search.Edge[*node], len(n.neighbors))
 	for i, n := range n.neighbors {
-		neighbors[i] = n
+		edges[i] = search.Edge[*node]{To: n, Cost: 1}
 	}
-	return neighbors
-}
-
-func (n *node) PathNeighborCost(to astar.Pather) float64 {
-	return 1
-}
-
-func (n *node) PathEstimatedCost(to astar.Pather) float64 {
-	other := to.(*node)
-	return float64(abs(n.p.r-other.p.r) + abs(n.p.c-other.p.c))
+	return edges
 }
 
 func (n *node) String() string {
@@ -115,7 +53,7 @@ type cpu struct {
 func (c cpu) Print(path []point) {
 	for row := 0; row < c.h; row++ {
 		for col := 0; col < c.w; col++ {
-			p := point{row, col}
+			p := point{X: col, Y: row}
 			if _, ok := c.walls[p]; ok {
 				fmt.Print("#")
 			} else if p == c.s {
@@ -134,20 +72,19 @@ func (c cpu) Print(path []point) {
 	}
 }
 
-func (c *cpu) findPathWithAstar() int {
+func (c *cpu) findPath() int {
 	nstart := c.track[c.s]
 	nend := c.track[c.e]
-	path, distance, found := astar.Path(nstart, nend)
+	path, distance, found := search.AStar(nstart, func(n *node) bool { return n == nend }, (*node).steps,
+		func(n *node) int { return n.p.Manhattan(nend.p) })
 	if !found {
 		return -1
 	}
-	for i := 0; i < len(path); i++ {
-		// path was generated in reverse order
-		n := path[len(path)-i-1].(*node)
+	for i, n := range path {
 		n.t = i
 		c.path = append(c.path, n.p)
 	}
-	return int(distance)
+	return distance
 }
 
 func (c *cpu) allTunnelsAt(p point, md int) map[point]struct{} {
@@ -156,19 +93,19 @@ func (c *cpu) allTunnelsAt(p point, md int) map[point]struct{} {
 	// find all tunnels at this distance
 	for dr := 0; dr <= md; dr += 1 {
 		dc := md - dr
-		pt := point{p.r + dr, p.c + dc}
+		pt := p.Add(point{X: dc, Y: dr})
 		if _, ok := c.track[pt]; ok {
 			tunnels[pt] = struct{}{}
 		}
-		pt = point{p.r + dr, p.c - dc}
+		pt = p.Add(point{X: -dc, Y: dr})
 		if _, ok := c.track[pt]; ok {
 			tunnels[pt] = struct{}{}
 		}
-		pt = point{p.r - dr, p.c + dc}
+		pt = p.Add(point{X: dc, Y: -dr})
 		if _, ok := c.track[pt]; ok {
 			tunnels[pt] = struct{}{}
 		}
-		pt = point{p.r - dr, p.c - dc}
+		pt = p.Add(point{X: -dc, Y: -dr})
 		if _, ok := c.track[pt]; ok {
 			tunnels[pt] = struct{}{}
 		}
@@ -186,10 +123,10 @@ func parseCPU(lines []string) *cpu {
 	for r, line := range lines {
 		for c, char := range line {
 			if char == '#' {
-				cpu.walls[point{r, c}] = struct{}{}
+				cpu.walls[point{X: c, Y: r}] = struct{}{}
 				continue
 			}
-			p := point{r, c}
+			p := point{X: c, Y: r}
 			n := &node{p: p, neighbors: make([]*node, 0)}
 			cpu.track[p] = n
 			if char == 'S' {
@@ -204,11 +141,11 @@ func parseCPU(lines []string) *cpu {
 	}
 	for p, n := range cpu.track {
 		for _, d := range []direction{north, south, east, west} {
-			v, ok := cpu.track[p.next(d)]
+			v, ok := cpu.track[p.Move(d)]
 			if ok {
 				n.neighbors = append(n.neighbors, v)
 			}
-			v, ok = cpu.track[p.tunnel(d)]
+			v, ok = cpu.track[p.Move(d).Move(d)]
 			if ok {
 				n.tunnels = append(n.tunnels, v)
 			}
@@ -220,7 +157,7 @@ func parseCPU(lines []string) *cpu {
 func part1(lines []string) int {
 	c := parseCPU(lines)
 	// c.Print(nil)
-	_ = c.findPathWithAstar()
+	_ = c.findPath()
 	c.Print(c.path)
 	savings := map[int]int{}
 	for _, p := range c.path {
@@ -246,7 +183,7 @@ func part1(lines []string) int {
 func part2(lines []string) int {
 	c := parseCPU(lines)
 	// c.Print(nil)
-	_ = c.findPathWithAstar()
+	_ = c.findPath()
 	c.Print(c.path)
 	savings := map[int]int{}
 	for _, p := range c.path {
@@ -275,25 +212,6 @@ func part2(lines []string) int {
 	return total
 }
 
-func readlines(filename string) []string {
-	f, err := os.Open(fmt.Sprintf("./data/%s.txt", filename))
-	if err != nil {
-		log.Fatal(err)
-	}
-	b, err := io.ReadAll(f)
-	if err != nil {
-		log.Fatal(err)
-	}
-	return strings.Split(string(b), "\n")
-}
-
-func main() {
-	args := os.Args[1:]
-	filename := "sample"
-	if len(args) > 0 {
-		filename = args[0]
-	}
-	lines := readlines(filename)
-	fmt.Println(part1(lines))
-	fmt.Println(part2(lines))
+func init() {
+	aoc.Register(20, aoc.Parts{aoc.OnLines(part1), aoc.OnLines(part2)})
 }
@@ -1,12 +1,10 @@
-package main
+package day22
 
 import (
 	"fmt"
-	"io"
-	"log"
-	"os"
 	"strconv"
-	"strings"
+
+	"github.com/kentquirk/aoc2024/aoc"
 )
 
 type monkey struct {
@@ -69,9 +67,15 @@ func part1(lines []string) int {
 	return total
 }
 
-func part2(lines []string) int {
+// part2 finds the best sequence of changes to sell on. Working out each
+// monkey's prices is the slow part, so it checks stopped before each one,
+// and gives up if it says to.
+func part2(lines []string, stopped func() bool) (int, error) {
 	monkeys := make([]*monkey, len(lines))
 	for i, line := range lines {
+		if stopped() {
+			return 0, aoc.ErrStopped
+		}
 		x, _ := strconv.Atoi(line)
 		m := newMonkey(x)
 		monkeys[i] = m
@@ -102,28 +106,12 @@ func part2(lines []string) int {
 		}
 	}
 	fmt.Println(maxkey, maxvalue)
-	return maxvalue
+	return maxvalue, nil
 }
 
-func readlines(filename string) []string {
-	f, err := os.Open(fmt.Sprintf("./data/%s.txt", filename))
-	if err != nil {
-		log.Fatal(err)
-	}
-	b, err := io.ReadAll(f)
-	if err != nil {
-		log.Fatal(err)
-	}
-	return strings.Split(string(b), "\n")
-}
-
-func main() {
-	args := os.Args[1:]
-	filename := "sample"
-	if len(args) > 0 {
-		filename = args[0]
-	}
-	lines := readlines(filename)
-	fmt.Println("Part 1: ", part1(lines))
-	fmt.Println("Part 2: ", part2(lines))
+func init() {
+	aoc.Register(22, aoc.Parts{
+		aoc.OnLines(part1),
+		func(in *aoc.Input) (any, error) { return part2(in.Lines, in.Stopped) },
+	})
 }
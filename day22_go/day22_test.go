@@ -0,0 +1,15 @@
+package day22
+
+import (
+	"testing"
+
+	"github.com/kentquirk/aoc2024/aoc/aoctest"
+)
+
+func TestAnswers(t *testing.T) {
+	aoctest.Answers(t, 22)
+}
+
+func BenchmarkParts(b *testing.B) {
+	aoctest.Benchmark(b, 22)
+}
@@ -1,12 +1,13 @@
-package main
+package day23
 
 import (
 	"fmt"
-	"io"
-	"log"
-	"os"
+	"maps"
+	"slices"
 	"sort"
 	"strings"
+
+	"github.com/kentquirk/aoc2024/aoc"
 )
 
 type graph struct {
@@ -106,7 +107,10 @@ func part1(lines []string) int {
 	return countTs
 }
 
-func part2(lines []string) int {
+// part2 grows the triangles a node at a time until they can't grow any
+// more. What's left is the biggest group where everyone is connected to
+// everyone else, and its names in order are the password for the LAN party.
+func part2(lines []string) string {
 	g := newGraph()
 	for _, line := range lines {
 		parts := strings.Split(line, "-")
@@ -124,28 +128,14 @@ func part2(lines []string) int {
 		groups = nextGroups
 	}
 	fmt.Println(groups)
-	return len(groups)
-}
-
-func readlines(filename string) []string {
-	f, err := os.Open(fmt.Sprintf("./data/%s.txt", filename))
-	if err != nil {
-		log.Fatal(err)
+	// if more than one group is biggest, take the first in order
+	keys := slices.Sorted(maps.Keys(groups))
+	if len(keys) == 0 {
+		return ""
 	}
-	b, err := io.ReadAll(f)
-	if err != nil {
-		log.Fatal(err)
-	}
-	return strings.Split(string(b), "\n")
+	return keys[0]
 }
 
-func main() {
-	args := os.Args[1:]
-	filename := "sample"
-	if len(args) > 0 {
-		filename = args[0]
-	}
-	lines := readlines(filename)
-	fmt.Println(part1(lines))
-	fmt.Println(part2(lines))
+func init() {
+	aoc.Register(23, aoc.Parts{aoc.OnLines(part1), aoc.OnLines(part2)})
 }
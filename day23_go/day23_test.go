@@ -0,0 +1,15 @@
+package day23
+
+import (
+	"testing"
+
+	"github.com/kentquirk/aoc2024/aoc/aoctest"
+)
+
+func TestAnswers(t *testing.T) {
+	aoctest.Answers(t, 23)
+}
+
+func BenchmarkParts(b *testing.B) {
+	aoctest.Benchmark(b, 23)
+}
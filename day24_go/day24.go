@@ -1,14 +1,13 @@
-package main
+package day24
 
 import (
 	"fmt"
-	"io"
-	"log"
 	"maps"
-	"os"
 	"slices"
 	"strconv"
 	"strings"
+
+	"github.com/kentquirk/aoc2024/aoc"
 )
 
 type signal struct {
@@ -151,6 +150,17 @@ func (s *system) getValue() int {
 	return value
 }
 
+// get reads the number held by the signals with the given prefix
+func (s *system) get(prefix string) int {
+	var value int
+	for _, sig := range s.signals {
+		if sig.bitIndex != -1 && strings.HasPrefix(sig.name, prefix) && sig.value {
+			value |= 1 << sig.bitIndex
+		}
+	}
+	return value
+}
+
 func (s *system) set(prefix string, value int) {
 	for _, sig := range s.signals {
 		if sig.bitIndex != -1 && strings.HasPrefix(sig.name, prefix) {
@@ -223,6 +233,8 @@ func part1(lines []string, x, y int) int {
 	return s.getValue()
 }
 
+// I did part 2 manually by feeding the data to a dot file and looking for inconsistencies using graphviz
+// on GraphvizOnline. The dot engine was useful, but the fdp engine was better for this particular problem.
 func part2(lines []string) int {
 	s := parseLines(lines)
 
@@ -230,35 +242,23 @@ func part2(lines []string) int {
 	return 0
 }
 
-func readlines(filename string) []string {
-	f, err := os.Open(fmt.Sprintf("./data/%s.txt", filename))
-	if err != nil {
-		log.Fatal(err)
-	}
-	b, err := io.ReadAll(f)
-	if err != nil {
-		log.Fatal(err)
-	}
-	return strings.Split(string(b), "\n")
-}
-
-// I did part 2 manually by feeding the data to a dot file and looking for inconsistencies using graphviz
-// on GraphvizOnline. The dot engine was useful, but the fdp engine was better for this particular problem.
-func main() {
-	args := os.Args[1:]
-	filename := "inputFixed"
-	if len(args) > 0 {
-		filename = args[0]
-	}
-	lines := readlines(filename)
-	// x := 0x3
-	// y := 0x3
-	// if len(args) > 1 {
-	// 	x, _ = strconv.Atoi(args[1])
-	// 	y, _ = strconv.Atoi(args[2])
-	// }
-	// answer := part1(lines, x, y)
-	// fmt.Printf("%d + %d = %d\n", x, y, answer)
-	// fmt.Printf("%x + %x = %x\n", x, y, answer)
-	part2(lines)
+func init() {
+	aoc.Register(24, aoc.Parts{
+		// part1 takes the x and y inputs from the puzzle unless they're given as arguments
+		func(in *aoc.Input) (any, error) {
+			s := parseLines(in.Lines)
+			x, y := s.get("x"), s.get("y")
+			if len(in.Args) > 1 {
+				var err error
+				if x, err = strconv.Atoi(in.Args[0]); err != nil {
+					return nil, err
+				}
+				if y, err = strconv.Atoi(in.Args[1]); err != nil {
+					return nil, err
+				}
+			}
+			return part1(in.Lines, x, y), nil
+		},
+		aoc.OnLines(part2),
+	})
 }
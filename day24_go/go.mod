@@ -1,3 +1,7 @@
 module github.com/kentquirk/aoc2024/day24
 
 go 1.23
+
+require github.com/kentquirk/aoc2024 v0.0.0
+
+replace github.com/kentquirk/aoc2024 => ../
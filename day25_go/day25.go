@@ -1,11 +1,10 @@
-package main
+package day25
 
 import (
 	"fmt"
-	"io"
-	"log"
-	"os"
 	"strings"
+
+	"github.com/kentquirk/aoc2024/aoc"
 )
 
 const (
@@ -140,8 +139,8 @@ func parseOne(lines []string) (*shape, int) {
 func part1(lines []string) int {
 	keys := newShapeTree()
 	locks := newShapeTree()
-	for i := 0; i < len(lines); i += 8 {
-		shape, kind := parseOne(lines[i : i+7])
+	for _, block := range aoc.SplitParagraphs(lines) {
+		shape, kind := parseOne(block)
 		if kind == KEY {
 			keys.add(shape.cols, shape)
 		} else {
@@ -166,24 +165,6 @@ func part2(lines []string) int {
 	return 0
 }
 
-func readlines(filename string) []string {
-	f, err := os.Open(fmt.Sprintf("./data/%s.txt", filename))
-	if err != nil {
-		log.Fatal(err)
-	}
-	b, err := io.ReadAll(f)
-	if err != nil {
-		log.Fatal(err)
-	}
-	return strings.Split(string(b), "\n")
-}
-
-func main() {
-	args := os.Args[1:]
-	filename := "sample"
-	if len(args) > 0 {
-		filename = args[0]
-	}
-	lines := readlines(filename)
-	fmt.Println(part1(lines))
+func init() {
+	aoc.Register(25, aoc.Parts{aoc.OnLines(part1), aoc.OnLines(part2)})
 }
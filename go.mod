@@ -0,0 +1,3 @@
+module github.com/kentquirk/aoc2024
+
+go 1.23